@@ -8,53 +8,191 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 
 	libsentry "github.com/bborbe/sentry"
 	"github.com/bborbe/service"
 
+	"github.com/bborbe/obsidian-lint/pkg/cache"
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
 	"github.com/bborbe/obsidian-lint/pkg/formatter"
+	"github.com/bborbe/obsidian-lint/pkg/git"
 	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/lsp"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/mover"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
 	"github.com/bborbe/obsidian-lint/pkg/resolver"
 	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/settings"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
+	"github.com/bborbe/obsidian-lint/pkg/trash"
 	"github.com/bborbe/obsidian-lint/pkg/validator"
+	"github.com/bborbe/obsidian-lint/pkg/watcher"
 )
 
 func main() {
 	app := &application{}
+	app.command, app.params, os.Args = splitCommand(os.Args)
 	os.Exit(service.Main(context.Background(), app, &app.SentryDSN, &app.SentryProxy))
 }
 
 type application struct {
-	SentryDSN   string `required:"false" arg:"sentry-dsn"   env:"SENTRY_DSN"   usage:"SentryDSN (optional)"      display:"length"`
-	SentryProxy string `required:"false" arg:"sentry-proxy" env:"SENTRY_PROXY" usage:"Sentry Proxy"`
-	Vault       string `required:"true"  arg:"vault"        env:"VAULT"        usage:"vault directory path"`
-	Format      string `required:"false" arg:"format"       env:"FORMAT"       usage:"output format (text|json)"                  default:"text"`
+	SentryDSN     string `required:"false" arg:"sentry-dsn"     env:"SENTRY_DSN"     usage:"SentryDSN (optional)"                                             display:"length"`
+	SentryProxy   string `required:"false" arg:"sentry-proxy"   env:"SENTRY_PROXY"   usage:"Sentry Proxy"`
+	Vault         string `required:"true"  arg:"vault"          env:"VAULT"          usage:"vault directory path"`
+	Config        string `required:"false" arg:"config"         env:"CONFIG"         usage:"config file (default: .obsidian-lint.yaml in vault)"`
+	Format        string `required:"false" arg:"format"         env:"FORMAT"         usage:"output format (text|json|sarif|junit|checkstyle|github|gitlab), overrides config"`
+	PreferClosest bool   `required:"false" arg:"prefer-closest" env:"PREFER_CLOSEST" usage:"resolve ambiguous links like Obsidian (same folder, then shortest path)" default:"false"`
+	Exclude       string `required:"false" arg:"exclude"        env:"EXCLUDE"        usage:"comma separated glob patterns of vault paths to skip"`
+	DeleteUnused  bool   `required:"false" arg:"delete-unused"  env:"DELETE_UNUSED"  usage:"move unused attachments to the vault .trash folder"                      default:"false"`
+	Fix           bool   `required:"false" arg:"fix"            env:"FIX"            usage:"rewrite broken links with exactly one confident match"                   default:"false"`
+	DryRun        bool   `required:"false" arg:"dry-run"        env:"DRY_RUN"        usage:"with --fix or mv print a diff, with --delete-unused list files only"     default:"false"`
+	Workers       int    `required:"false" arg:"workers"        env:"WORKERS"        usage:"number of files parsed in parallel (default: one per CPU), overrides config"`
+	NoCache       bool   `required:"false" arg:"no-cache"       env:"NO_CACHE"       usage:"parse all notes instead of reusing .obsidian-lint-cache in the vault"    default:"false"`
+	ChangedSince  string `required:"false" arg:"changed-since"  env:"CHANGED_SINCE"  usage:"only report notes changed in git since this ref"`
+	Staged        bool   `required:"false" arg:"staged"         env:"STAGED"         usage:"only report notes changed in the git index"                              default:"false"`
+
+	command string   // subcommand, empty for linting
+	params  []string // arguments of the subcommand
 }
 
+// splitCommand separates the subcommand and its arguments from the program name and
+// the flags. They may come before, between or after the flags, all arguments after
+// "--" are arguments.
+func splitCommand(args []string) (string, []string, []string) {
+	flags := []string{args[0]}
+	var positional []string
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		// The value of a flag without = is the next argument, except for bool flags
+		name := strings.TrimLeft(arg, "-")
+		if !strings.Contains(name, "=") && !boolFlags[name] && i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+	if len(positional) == 0 {
+		return "", nil, flags
+	}
+	return positional[0], positional[1:], flags
+}
+
+// boolFlags are the flags of application without value
+var boolFlags = func() map[string]bool {
+	flags := map[string]bool{"h": true, "help": true}
+	t := reflect.TypeOf(application{})
+	for i := 0; i < t.NumField(); i++ {
+		if arg := t.Field(i).Tag.Get("arg"); arg != "" && t.Field(i).Type.Kind() == reflect.Bool {
+			flags[arg] = true
+		}
+	}
+	return flags
+}()
+
 func (a *application) Run(ctx context.Context, sentryClient libsentry.Client) error {
+	cfg, err := a.loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Skip files excluded in Obsidian settings, config or on the command line
+	obsidianSettings, err := settings.New().Load(ctx, a.Vault)
+	if err != nil {
+		return err
+	}
+	m := exclude.New(obsidianSettings.UserIgnoreFilters, cfg.Ignore)
+	if cfg.Attachments.Folder == "" {
+		cfg.Attachments.Folder = obsidianSettings.AttachmentFolder()
+	}
+	if cfg.LinkFormat == "" {
+		cfg.LinkFormat = obsidianSettings.LinkFormat()
+	}
+
+	f, err := newFormatter(cfg.Format)
+	if err != nil {
+		return err
+	}
+
 	// Build dependencies
-	s := scanner.New()
+	s := scanner.New(m)
 	p := parser.New()
-	b := index.New(p)
-	r := resolver.New()
-	v := validator.New(s, p, b, r)
+	var store cache.Store
+	if !a.NoCache {
+		store, err = cache.Load(ctx, a.Vault)
+		if err != nil {
+			return err
+		}
+		p = cache.NewParser(p, store)
+	}
+	b := index.New(p, m, cfg.Workers)
+	r := resolver.New(cfg)
+	v := validator.New(s, p, b, r, suggest.New(), schema.New(cfg.Schemas), cfg)
+
+	switch a.command {
+	case "":
+		return a.lint(ctx, v, f, store)
+	case "watch":
+		if len(a.params) > 0 {
+			return fmt.Errorf("watch takes no arguments")
+		}
+		if err := watcher.New(s, p, b, v, m, f, os.Stdout).Watch(ctx, a.Vault); err != nil {
+			return err
+		}
+		saveCache(ctx, store)
+		return nil
+	case "lsp":
+		if len(a.params) > 0 {
+			return fmt.Errorf("lsp takes no arguments")
+		}
+		if err := lsp.New(s, p, b, r, v).Serve(ctx, a.Vault, os.Stdin, os.Stdout); err != nil {
+			return err
+		}
+		saveCache(ctx, store)
+		return nil
+	case "mv":
+		if len(a.params) != 2 {
+			return fmt.Errorf("usage: mv <old> <new>")
+		}
+		return a.move(ctx, s, b, mover.New(r, cfg.LinkFormat))
+	default:
+		return fmt.Errorf("unknown command: %s (must be watch, lsp or mv)", a.command)
+	}
+}
 
-	// Validate vault
-	result, err := v.Validate(ctx, a.Vault)
+// lint validates the vault once, prints the findings and exits with a non-zero
+// code if findings with error severity are found
+func (a *application) lint(
+	ctx context.Context,
+	v validator.Validator,
+	f formatter.Formatter,
+	store cache.Store,
+) error {
+	result, err := a.validate(ctx, v)
 	if err != nil {
 		return err
 	}
+	saveCache(ctx, store)
 
-	// Format output
-	var f formatter.Formatter
-	switch a.Format {
-	case "json":
-		f = formatter.NewJSONFormatter()
-	case "text":
-		f = formatter.NewTextFormatter()
-	default:
-		return fmt.Errorf("invalid format: %s (must be 'text' or 'json')", a.Format)
+	if a.Fix {
+		if err := a.fix(ctx, result); err != nil {
+			return err
+		}
 	}
 
 	output, err := f.Format(ctx, result)
@@ -65,10 +203,255 @@ func (a *application) Run(ctx context.Context, sentryClient libsentry.Client) er
 	// Print output
 	fmt.Print(output)
 
-	// Exit with non-zero if broken links found
-	if len(result.BrokenLinks) > 0 {
+	if a.DeleteUnused {
+		if err := a.deleteUnused(ctx, result); err != nil {
+			return err
+		}
+	}
+
+	// Exit with non-zero if findings with error severity found
+	if result.HasErrors() {
 		os.Exit(1)
 	}
 
 	return nil
 }
+
+// saveCache writes the parse cache, unless it is disabled with --no-cache. A cache
+// that can not be written (read-only vault, full disk) only costs speed, so the
+// error is logged as warning.
+func saveCache(ctx context.Context, store cache.Store) {
+	if store == nil {
+		return
+	}
+	if err := store.Save(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: save cache failed: %v\n", err)
+	}
+}
+
+// newFormatter returns the formatter of an output format
+func newFormatter(format string) (formatter.Formatter, error) {
+	switch format {
+	case "json":
+		return formatter.NewJSONFormatter(), nil
+	case "sarif":
+		return formatter.NewSARIFFormatter(), nil
+	case "junit":
+		return formatter.NewJUnitFormatter(), nil
+	case "checkstyle":
+		return formatter.NewCheckstyleFormatter(), nil
+	case "github":
+		return formatter.NewGitHubFormatter(), nil
+	case "gitlab":
+		return formatter.NewGitLabFormatter(), nil
+	case "text":
+		return formatter.NewTextFormatter(), nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid format: %s (must be text, json, sarif, junit, checkstyle, github or gitlab)",
+			format,
+		)
+	}
+}
+
+// validate checks the vault and restricts findings to notes changed in git with
+// --changed-since or --staged
+func (a *application) validate(
+	ctx context.Context,
+	v validator.Validator,
+) (*model.ValidationResult, error) {
+	var diff *git.Diff
+	var err error
+	switch {
+	case a.ChangedSince != "" && a.Staged:
+		return nil, fmt.Errorf("--changed-since and --staged can not be combined")
+	case a.ChangedSince != "":
+		diff, err = git.New().ChangedSince(ctx, a.Vault, a.ChangedSince)
+	case a.Staged:
+		diff, err = git.New().Staged(ctx, a.Vault)
+	default:
+		return v.Validate(ctx, a.Vault)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v.ValidateDiff(ctx, a.Vault, diff)
+}
+
+// loadConfig reads the config file and applies command line overrides
+func (a *application) loadConfig(ctx context.Context) (*config.Config, error) {
+	cfg, err := config.New().Load(ctx, a.Vault, a.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Format != "" {
+		cfg.Format = a.Format
+	}
+	if a.PreferClosest {
+		cfg.PreferClosest = true
+	}
+	if a.Workers > 0 {
+		cfg.Workers = a.Workers
+	}
+	for _, pattern := range strings.Split(a.Exclude, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			cfg.Ignore = append(cfg.Ignore, pattern)
+		}
+	}
+
+	// Deleting unused attachments needs the check, even if the config switches it off
+	if a.DeleteUnused && !cfg.Enabled(model.KindUnusedAttachment) {
+		if cfg.Rules == nil {
+			cfg.Rules = make(map[model.Kind]config.Rule)
+		}
+		cfg.Rules[model.KindUnusedAttachment] = config.Rule{Severity: model.SeverityWarning}
+	}
+
+	return cfg, nil
+}
+
+// fix rewrites broken links with a fix and removes them from result, or prints
+// a diff of the changes with --dry-run. Progress and diff go to stderr, so the
+// report on stdout stays parseable in every format.
+func (a *application) fix(ctx context.Context, result *model.ValidationResult) error {
+	f := fixer.New()
+	changes, err := f.Plan(ctx, result)
+	if err != nil {
+		return err
+	}
+
+	if a.DryRun {
+		for _, change := range changes {
+			name, err := filepath.Rel(a.Vault, change.File)
+			if err != nil {
+				name = change.File
+			}
+			fmt.Fprint(os.Stderr, fixer.Diff(change, filepath.ToSlash(name)))
+		}
+		return nil
+	}
+
+	if err := f.Apply(ctx, changes); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		fixed := make(map[int]bool, len(change.Edits))
+		for _, edit := range change.Edits {
+			fmt.Fprintf(
+				os.Stderr,
+				"Fixed %s:%d: %s -> %s\n",
+				change.File,
+				edit.Line,
+				edit.Old,
+				edit.New,
+			)
+			fixed[edit.Offset] = true
+		}
+
+		var remaining []model.BrokenLink
+		for _, brokenLink := range result.BrokenLinks[change.File] {
+			if brokenLink.Fix == "" || !fixed[brokenLink.Offset] {
+				remaining = append(remaining, brokenLink)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(result.BrokenLinks, change.File)
+			continue
+		}
+		result.BrokenLinks[change.File] = remaining
+	}
+	if len(changes) > 0 {
+		fmt.Fprintln(os.Stderr)
+	}
+	return nil
+}
+
+// move renames or moves the file given as first mv argument to the second and
+// rewrites all links to it. Relative paths are relative to the vault, moving
+// into an existing folder keeps the file name.
+func (a *application) move(
+	ctx context.Context,
+	s scanner.Scanner,
+	b index.Builder,
+	mv mover.Mover,
+) error {
+	oldPath := a.vaultPath(a.params[0])
+	newPath := a.vaultPath(a.params[1])
+	if info, err := os.Stat(newPath); err == nil && info.IsDir() {
+		newPath = filepath.Join(newPath, filepath.Base(oldPath))
+	}
+
+	files, err := s.Scan(ctx, a.Vault)
+	if err != nil {
+		return err
+	}
+	idx, err := b.Build(ctx, a.Vault, files)
+	if err != nil {
+		return err
+	}
+	changes, err := mv.Plan(ctx, idx, oldPath, newPath)
+	if err != nil {
+		return err
+	}
+
+	links := 0
+	for _, change := range changes {
+		links += len(change.Edits)
+	}
+	if a.DryRun {
+		fmt.Printf("Move %s -> %s\n", idx.RelPath(oldPath), idx.RelPath(newPath))
+		for _, change := range changes {
+			fmt.Print(fixer.Diff(change, idx.RelPath(change.File)))
+		}
+		return nil
+	}
+
+	if err := mv.Move(ctx, oldPath, newPath, changes); err != nil {
+		return err
+	}
+	fmt.Printf(
+		"Moved %s -> %s, updated %d links in %d files\n",
+		idx.RelPath(oldPath),
+		idx.RelPath(newPath),
+		links,
+		len(changes),
+	)
+	return nil
+}
+
+// vaultPath returns path relative to the vault unless it is absolute
+func (a *application) vaultPath(path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(a.Vault, path)
+}
+
+// deleteUnused moves all unused attachments found into the vault trash, or only
+// lists them with --dry-run. Progress goes to stderr to keep the report parseable.
+func (a *application) deleteUnused(ctx context.Context, result *model.ValidationResult) error {
+	t := trash.New()
+	files := make([]string, 0, len(result.BrokenLinks))
+	for file, brokenLinks := range result.BrokenLinks {
+		for _, brokenLink := range brokenLinks {
+			if brokenLink.Kind == model.KindUnusedAttachment {
+				files = append(files, file)
+			}
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if a.DryRun {
+			fmt.Fprintf(os.Stderr, "Would move %s to %s\n", file, trash.Folder)
+			continue
+		}
+		target, err := t.Move(ctx, a.Vault, file)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Moved %s to %s\n", file, target)
+	}
+	return nil
+}
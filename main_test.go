@@ -14,11 +14,16 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
 
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
 	"github.com/bborbe/obsidian-lint/pkg/formatter"
 	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
 	"github.com/bborbe/obsidian-lint/pkg/resolver"
 	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
 	"github.com/bborbe/obsidian-lint/pkg/validator"
 )
 
@@ -56,7 +61,7 @@ var _ = Describe("Integration", func() {
 		note3 := filepath.Join(tempDir, "WithAlias.md")
 		image := filepath.Join(tempDir, "image.png")
 
-		Expect(os.WriteFile(note1, []byte("This is a valid note."), 0600)).To(Succeed())
+		Expect(os.WriteFile(note1, []byte("# Heading\n\nThis is a valid note."), 0600)).To(Succeed())
 		Expect(os.WriteFile(note2, []byte(`# Broken Links Test
 
 This links to [[ValidNote]] which exists.
@@ -64,7 +69,8 @@ This links to [[DoesNotExist]] which is broken.
 This embeds ![[image.png]] which exists.
 This embeds ![[missing.png]] which is broken.
 This links to [[MyAlias]] which resolves via alias.
-This links to [[ValidNote#Heading]] which is valid (heading not checked).
+This links to [[ValidNote#Heading]] which is valid.
+This links to [[ValidNote#Missing Heading]] which is broken.
 `), 0600)).To(Succeed())
 		Expect(os.WriteFile(note3, []byte(`---
 aliases: [MyAlias, Another]
@@ -73,24 +79,28 @@ Content here.`), 0600)).To(Succeed())
 		Expect(os.WriteFile(image, []byte("fake image data"), 0600)).To(Succeed())
 
 		// Run validation
-		s := scanner.New()
+		m := exclude.New(nil, nil)
+		s := scanner.New(m)
 		p := parser.New()
-		b := index.New(p)
-		r := resolver.New()
-		v := validator.New(s, p, b, r)
+		b := index.New(p, m, 0)
+		r := resolver.New(config.Default())
+		v := validator.New(s, p, b, r, suggest.New(), schema.New(nil), config.Default())
 
 		result, err := v.Validate(ctx, tempDir)
 		Expect(err).NotTo(HaveOccurred())
 
 		// Verify results
 		Expect(result.BrokenLinks).To(HaveLen(1))
-		Expect(result.BrokenLinks[note2]).To(HaveLen(2))
+		Expect(result.BrokenLinks[note2]).To(HaveLen(3))
 
 		brokenLinks := result.BrokenLinks[note2]
 		Expect(brokenLinks[0].Link).To(Equal("[[DoesNotExist]]"))
 		Expect(brokenLinks[0].Line).To(Equal(4))
 		Expect(brokenLinks[1].Link).To(Equal("![[missing.png]]"))
 		Expect(brokenLinks[1].Line).To(Equal(6))
+		Expect(brokenLinks[2].Link).To(Equal("[[ValidNote#Missing Heading]]"))
+		Expect(brokenLinks[2].Line).To(Equal(9))
+		Expect(brokenLinks[2].Kind).To(Equal(model.KindBrokenHeading))
 
 		// Test text formatter
 		textFormatter := formatter.NewTextFormatter()
@@ -118,11 +128,12 @@ Content here.`), 0600)).To(Succeed())
 		Expect(os.WriteFile(note2, []byte("Link to [[Note1]]"), 0600)).To(Succeed())
 
 		// Run validation
-		s := scanner.New()
+		m := exclude.New(nil, nil)
+		s := scanner.New(m)
 		p := parser.New()
-		b := index.New(p)
-		r := resolver.New()
-		v := validator.New(s, p, b, r)
+		b := index.New(p, m, 0)
+		r := resolver.New(config.Default())
+		v := validator.New(s, p, b, r, suggest.New(), schema.New(nil), config.Default())
 
 		result, err := v.Validate(ctx, tempDir)
 		Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+type Fixer struct {
+	ApplyStub        func(context.Context, []fixer.Change) error
+	applyMutex       sync.RWMutex
+	applyArgsForCall []struct {
+		arg1 context.Context
+		arg2 []fixer.Change
+	}
+	applyReturns struct {
+		result1 error
+	}
+	applyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PlanStub        func(context.Context, *model.ValidationResult) ([]fixer.Change, error)
+	planMutex       sync.RWMutex
+	planArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.ValidationResult
+	}
+	planReturns struct {
+		result1 []fixer.Change
+		result2 error
+	}
+	planReturnsOnCall map[int]struct {
+		result1 []fixer.Change
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Fixer) Apply(arg1 context.Context, arg2 []fixer.Change) error {
+	var arg2Copy []fixer.Change
+	if arg2 != nil {
+		arg2Copy = make([]fixer.Change, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.applyMutex.Lock()
+	ret, specificReturn := fake.applyReturnsOnCall[len(fake.applyArgsForCall)]
+	fake.applyArgsForCall = append(fake.applyArgsForCall, struct {
+		arg1 context.Context
+		arg2 []fixer.Change
+	}{arg1, arg2Copy})
+	stub := fake.ApplyStub
+	fakeReturns := fake.applyReturns
+	fake.recordInvocation("Apply", []interface{}{arg1, arg2Copy})
+	fake.applyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Fixer) ApplyCallCount() int {
+	fake.applyMutex.RLock()
+	defer fake.applyMutex.RUnlock()
+	return len(fake.applyArgsForCall)
+}
+
+func (fake *Fixer) ApplyCalls(stub func(context.Context, []fixer.Change) error) {
+	fake.applyMutex.Lock()
+	defer fake.applyMutex.Unlock()
+	fake.ApplyStub = stub
+}
+
+func (fake *Fixer) ApplyArgsForCall(i int) (context.Context, []fixer.Change) {
+	fake.applyMutex.RLock()
+	defer fake.applyMutex.RUnlock()
+	argsForCall := fake.applyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Fixer) ApplyReturns(result1 error) {
+	fake.applyMutex.Lock()
+	defer fake.applyMutex.Unlock()
+	fake.ApplyStub = nil
+	fake.applyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Fixer) ApplyReturnsOnCall(i int, result1 error) {
+	fake.applyMutex.Lock()
+	defer fake.applyMutex.Unlock()
+	fake.ApplyStub = nil
+	if fake.applyReturnsOnCall == nil {
+		fake.applyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Fixer) Plan(arg1 context.Context, arg2 *model.ValidationResult) ([]fixer.Change, error) {
+	fake.planMutex.Lock()
+	ret, specificReturn := fake.planReturnsOnCall[len(fake.planArgsForCall)]
+	fake.planArgsForCall = append(fake.planArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.ValidationResult
+	}{arg1, arg2})
+	stub := fake.PlanStub
+	fakeReturns := fake.planReturns
+	fake.recordInvocation("Plan", []interface{}{arg1, arg2})
+	fake.planMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Fixer) PlanCallCount() int {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	return len(fake.planArgsForCall)
+}
+
+func (fake *Fixer) PlanCalls(stub func(context.Context, *model.ValidationResult) ([]fixer.Change, error)) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = stub
+}
+
+func (fake *Fixer) PlanArgsForCall(i int) (context.Context, *model.ValidationResult) {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	argsForCall := fake.planArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Fixer) PlanReturns(result1 []fixer.Change, result2 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	fake.planReturns = struct {
+		result1 []fixer.Change
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Fixer) PlanReturnsOnCall(i int, result1 []fixer.Change, result2 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	if fake.planReturnsOnCall == nil {
+		fake.planReturnsOnCall = make(map[int]struct {
+			result1 []fixer.Change
+			result2 error
+		})
+	}
+	fake.planReturnsOnCall[i] = struct {
+		result1 []fixer.Change
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Fixer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Fixer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ fixer.Fixer = new(Fixer)
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/git"
+)
+
+type Git struct {
+	ChangedSinceStub        func(context.Context, string, string) (*git.Diff, error)
+	changedSinceMutex       sync.RWMutex
+	changedSinceArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	changedSinceReturns struct {
+		result1 *git.Diff
+		result2 error
+	}
+	changedSinceReturnsOnCall map[int]struct {
+		result1 *git.Diff
+		result2 error
+	}
+	StagedStub        func(context.Context, string) (*git.Diff, error)
+	stagedMutex       sync.RWMutex
+	stagedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	stagedReturns struct {
+		result1 *git.Diff
+		result2 error
+	}
+	stagedReturnsOnCall map[int]struct {
+		result1 *git.Diff
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Git) ChangedSince(arg1 context.Context, arg2 string, arg3 string) (*git.Diff, error) {
+	fake.changedSinceMutex.Lock()
+	ret, specificReturn := fake.changedSinceReturnsOnCall[len(fake.changedSinceArgsForCall)]
+	fake.changedSinceArgsForCall = append(fake.changedSinceArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ChangedSinceStub
+	fakeReturns := fake.changedSinceReturns
+	fake.recordInvocation("ChangedSince", []interface{}{arg1, arg2, arg3})
+	fake.changedSinceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Git) ChangedSinceCallCount() int {
+	fake.changedSinceMutex.RLock()
+	defer fake.changedSinceMutex.RUnlock()
+	return len(fake.changedSinceArgsForCall)
+}
+
+func (fake *Git) ChangedSinceCalls(stub func(context.Context, string, string) (*git.Diff, error)) {
+	fake.changedSinceMutex.Lock()
+	defer fake.changedSinceMutex.Unlock()
+	fake.ChangedSinceStub = stub
+}
+
+func (fake *Git) ChangedSinceArgsForCall(i int) (context.Context, string, string) {
+	fake.changedSinceMutex.RLock()
+	defer fake.changedSinceMutex.RUnlock()
+	argsForCall := fake.changedSinceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Git) ChangedSinceReturns(result1 *git.Diff, result2 error) {
+	fake.changedSinceMutex.Lock()
+	defer fake.changedSinceMutex.Unlock()
+	fake.ChangedSinceStub = nil
+	fake.changedSinceReturns = struct {
+		result1 *git.Diff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Git) ChangedSinceReturnsOnCall(i int, result1 *git.Diff, result2 error) {
+	fake.changedSinceMutex.Lock()
+	defer fake.changedSinceMutex.Unlock()
+	fake.ChangedSinceStub = nil
+	if fake.changedSinceReturnsOnCall == nil {
+		fake.changedSinceReturnsOnCall = make(map[int]struct {
+			result1 *git.Diff
+			result2 error
+		})
+	}
+	fake.changedSinceReturnsOnCall[i] = struct {
+		result1 *git.Diff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Git) Staged(arg1 context.Context, arg2 string) (*git.Diff, error) {
+	fake.stagedMutex.Lock()
+	ret, specificReturn := fake.stagedReturnsOnCall[len(fake.stagedArgsForCall)]
+	fake.stagedArgsForCall = append(fake.stagedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.StagedStub
+	fakeReturns := fake.stagedReturns
+	fake.recordInvocation("Staged", []interface{}{arg1, arg2})
+	fake.stagedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Git) StagedCallCount() int {
+	fake.stagedMutex.RLock()
+	defer fake.stagedMutex.RUnlock()
+	return len(fake.stagedArgsForCall)
+}
+
+func (fake *Git) StagedCalls(stub func(context.Context, string) (*git.Diff, error)) {
+	fake.stagedMutex.Lock()
+	defer fake.stagedMutex.Unlock()
+	fake.StagedStub = stub
+}
+
+func (fake *Git) StagedArgsForCall(i int) (context.Context, string) {
+	fake.stagedMutex.RLock()
+	defer fake.stagedMutex.RUnlock()
+	argsForCall := fake.stagedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Git) StagedReturns(result1 *git.Diff, result2 error) {
+	fake.stagedMutex.Lock()
+	defer fake.stagedMutex.Unlock()
+	fake.StagedStub = nil
+	fake.stagedReturns = struct {
+		result1 *git.Diff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Git) StagedReturnsOnCall(i int, result1 *git.Diff, result2 error) {
+	fake.stagedMutex.Lock()
+	defer fake.stagedMutex.Unlock()
+	fake.StagedStub = nil
+	if fake.stagedReturnsOnCall == nil {
+		fake.stagedReturnsOnCall = make(map[int]struct {
+			result1 *git.Diff
+			result2 error
+		})
+	}
+	fake.stagedReturnsOnCall[i] = struct {
+		result1 *git.Diff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Git) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Git) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ git.Git = new(Git)
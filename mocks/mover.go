@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/mover"
+)
+
+type Mover struct {
+	MoveStub        func(context.Context, string, string, []fixer.Change) error
+	moveMutex       sync.RWMutex
+	moveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []fixer.Change
+	}
+	moveReturns struct {
+		result1 error
+	}
+	moveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PlanStub        func(context.Context, *index.VaultIndex, string, string) ([]fixer.Change, error)
+	planMutex       sync.RWMutex
+	planArgsForCall []struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+		arg3 string
+		arg4 string
+	}
+	planReturns struct {
+		result1 []fixer.Change
+		result2 error
+	}
+	planReturnsOnCall map[int]struct {
+		result1 []fixer.Change
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Mover) Move(arg1 context.Context, arg2 string, arg3 string, arg4 []fixer.Change) error {
+	var arg4Copy []fixer.Change
+	if arg4 != nil {
+		arg4Copy = make([]fixer.Change, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.moveMutex.Lock()
+	ret, specificReturn := fake.moveReturnsOnCall[len(fake.moveArgsForCall)]
+	fake.moveArgsForCall = append(fake.moveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []fixer.Change
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.MoveStub
+	fakeReturns := fake.moveReturns
+	fake.recordInvocation("Move", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.moveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Mover) MoveCallCount() int {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	return len(fake.moveArgsForCall)
+}
+
+func (fake *Mover) MoveCalls(stub func(context.Context, string, string, []fixer.Change) error) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = stub
+}
+
+func (fake *Mover) MoveArgsForCall(i int) (context.Context, string, string, []fixer.Change) {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	argsForCall := fake.moveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Mover) MoveReturns(result1 error) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	fake.moveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Mover) MoveReturnsOnCall(i int, result1 error) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	if fake.moveReturnsOnCall == nil {
+		fake.moveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.moveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Mover) Plan(arg1 context.Context, arg2 *index.VaultIndex, arg3 string, arg4 string) ([]fixer.Change, error) {
+	fake.planMutex.Lock()
+	ret, specificReturn := fake.planReturnsOnCall[len(fake.planArgsForCall)]
+	fake.planArgsForCall = append(fake.planArgsForCall, struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PlanStub
+	fakeReturns := fake.planReturns
+	fake.recordInvocation("Plan", []interface{}{arg1, arg2, arg3, arg4})
+	fake.planMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Mover) PlanCallCount() int {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	return len(fake.planArgsForCall)
+}
+
+func (fake *Mover) PlanCalls(stub func(context.Context, *index.VaultIndex, string, string) ([]fixer.Change, error)) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = stub
+}
+
+func (fake *Mover) PlanArgsForCall(i int) (context.Context, *index.VaultIndex, string, string) {
+	fake.planMutex.RLock()
+	defer fake.planMutex.RUnlock()
+	argsForCall := fake.planArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Mover) PlanReturns(result1 []fixer.Change, result2 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	fake.planReturns = struct {
+		result1 []fixer.Change
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Mover) PlanReturnsOnCall(i int, result1 []fixer.Change, result2 error) {
+	fake.planMutex.Lock()
+	defer fake.planMutex.Unlock()
+	fake.PlanStub = nil
+	if fake.planReturnsOnCall == nil {
+		fake.planReturnsOnCall = make(map[int]struct {
+			result1 []fixer.Change
+			result2 error
+		})
+	}
+	fake.planReturnsOnCall[i] = struct {
+		result1 []fixer.Change
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Mover) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Mover) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ mover.Mover = new(Mover)
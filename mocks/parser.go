@@ -10,49 +10,64 @@ import (
 )
 
 type Parser struct {
-	ParseAliasesStub        func(context.Context, string) ([]string, error)
-	parseAliasesMutex       sync.RWMutex
-	parseAliasesArgsForCall []struct {
+	ParseStub        func(context.Context, string) (*model.ParsedFile, error)
+	parseMutex       sync.RWMutex
+	parseArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 	}
-	parseAliasesReturns struct {
-		result1 []string
+	parseReturns struct {
+		result1 *model.ParsedFile
 		result2 error
 	}
-	parseAliasesReturnsOnCall map[int]struct {
-		result1 []string
+	parseReturnsOnCall map[int]struct {
+		result1 *model.ParsedFile
 		result2 error
 	}
-	ParseFileStub        func(context.Context, string) ([]*model.Link, error)
-	parseFileMutex       sync.RWMutex
-	parseFileArgsForCall []struct {
+	ParseCanvasStub        func(context.Context, string) ([]*model.Link, error)
+	parseCanvasMutex       sync.RWMutex
+	parseCanvasArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 	}
-	parseFileReturns struct {
+	parseCanvasReturns struct {
 		result1 []*model.Link
 		result2 error
 	}
-	parseFileReturnsOnCall map[int]struct {
+	parseCanvasReturnsOnCall map[int]struct {
 		result1 []*model.Link
 		result2 error
 	}
+	ParseContentStub        func(context.Context, string, string) (*model.ParsedFile, error)
+	parseContentMutex       sync.RWMutex
+	parseContentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	parseContentReturns struct {
+		result1 *model.ParsedFile
+		result2 error
+	}
+	parseContentReturnsOnCall map[int]struct {
+		result1 *model.ParsedFile
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Parser) ParseAliases(arg1 context.Context, arg2 string) ([]string, error) {
-	fake.parseAliasesMutex.Lock()
-	ret, specificReturn := fake.parseAliasesReturnsOnCall[len(fake.parseAliasesArgsForCall)]
-	fake.parseAliasesArgsForCall = append(fake.parseAliasesArgsForCall, struct {
+func (fake *Parser) Parse(arg1 context.Context, arg2 string) (*model.ParsedFile, error) {
+	fake.parseMutex.Lock()
+	ret, specificReturn := fake.parseReturnsOnCall[len(fake.parseArgsForCall)]
+	fake.parseArgsForCall = append(fake.parseArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 	}{arg1, arg2})
-	stub := fake.ParseAliasesStub
-	fakeReturns := fake.parseAliasesReturns
-	fake.recordInvocation("ParseAliases", []interface{}{arg1, arg2})
-	fake.parseAliasesMutex.Unlock()
+	stub := fake.ParseStub
+	fakeReturns := fake.parseReturns
+	fake.recordInvocation("Parse", []interface{}{arg1, arg2})
+	fake.parseMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -62,62 +77,62 @@ func (fake *Parser) ParseAliases(arg1 context.Context, arg2 string) ([]string, e
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *Parser) ParseAliasesCallCount() int {
-	fake.parseAliasesMutex.RLock()
-	defer fake.parseAliasesMutex.RUnlock()
-	return len(fake.parseAliasesArgsForCall)
+func (fake *Parser) ParseCallCount() int {
+	fake.parseMutex.RLock()
+	defer fake.parseMutex.RUnlock()
+	return len(fake.parseArgsForCall)
 }
 
-func (fake *Parser) ParseAliasesCalls(stub func(context.Context, string) ([]string, error)) {
-	fake.parseAliasesMutex.Lock()
-	defer fake.parseAliasesMutex.Unlock()
-	fake.ParseAliasesStub = stub
+func (fake *Parser) ParseCalls(stub func(context.Context, string) (*model.ParsedFile, error)) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = stub
 }
 
-func (fake *Parser) ParseAliasesArgsForCall(i int) (context.Context, string) {
-	fake.parseAliasesMutex.RLock()
-	defer fake.parseAliasesMutex.RUnlock()
-	argsForCall := fake.parseAliasesArgsForCall[i]
+func (fake *Parser) ParseArgsForCall(i int) (context.Context, string) {
+	fake.parseMutex.RLock()
+	defer fake.parseMutex.RUnlock()
+	argsForCall := fake.parseArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Parser) ParseAliasesReturns(result1 []string, result2 error) {
-	fake.parseAliasesMutex.Lock()
-	defer fake.parseAliasesMutex.Unlock()
-	fake.ParseAliasesStub = nil
-	fake.parseAliasesReturns = struct {
-		result1 []string
+func (fake *Parser) ParseReturns(result1 *model.ParsedFile, result2 error) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = nil
+	fake.parseReturns = struct {
+		result1 *model.ParsedFile
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *Parser) ParseAliasesReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.parseAliasesMutex.Lock()
-	defer fake.parseAliasesMutex.Unlock()
-	fake.ParseAliasesStub = nil
-	if fake.parseAliasesReturnsOnCall == nil {
-		fake.parseAliasesReturnsOnCall = make(map[int]struct {
-			result1 []string
+func (fake *Parser) ParseReturnsOnCall(i int, result1 *model.ParsedFile, result2 error) {
+	fake.parseMutex.Lock()
+	defer fake.parseMutex.Unlock()
+	fake.ParseStub = nil
+	if fake.parseReturnsOnCall == nil {
+		fake.parseReturnsOnCall = make(map[int]struct {
+			result1 *model.ParsedFile
 			result2 error
 		})
 	}
-	fake.parseAliasesReturnsOnCall[i] = struct {
-		result1 []string
+	fake.parseReturnsOnCall[i] = struct {
+		result1 *model.ParsedFile
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *Parser) ParseFile(arg1 context.Context, arg2 string) ([]*model.Link, error) {
-	fake.parseFileMutex.Lock()
-	ret, specificReturn := fake.parseFileReturnsOnCall[len(fake.parseFileArgsForCall)]
-	fake.parseFileArgsForCall = append(fake.parseFileArgsForCall, struct {
+func (fake *Parser) ParseCanvas(arg1 context.Context, arg2 string) ([]*model.Link, error) {
+	fake.parseCanvasMutex.Lock()
+	ret, specificReturn := fake.parseCanvasReturnsOnCall[len(fake.parseCanvasArgsForCall)]
+	fake.parseCanvasArgsForCall = append(fake.parseCanvasArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 	}{arg1, arg2})
-	stub := fake.ParseFileStub
-	fakeReturns := fake.parseFileReturns
-	fake.recordInvocation("ParseFile", []interface{}{arg1, arg2})
-	fake.parseFileMutex.Unlock()
+	stub := fake.ParseCanvasStub
+	fakeReturns := fake.parseCanvasReturns
+	fake.recordInvocation("ParseCanvas", []interface{}{arg1, arg2})
+	fake.parseCanvasMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -127,51 +142,117 @@ func (fake *Parser) ParseFile(arg1 context.Context, arg2 string) ([]*model.Link,
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *Parser) ParseFileCallCount() int {
-	fake.parseFileMutex.RLock()
-	defer fake.parseFileMutex.RUnlock()
-	return len(fake.parseFileArgsForCall)
+func (fake *Parser) ParseCanvasCallCount() int {
+	fake.parseCanvasMutex.RLock()
+	defer fake.parseCanvasMutex.RUnlock()
+	return len(fake.parseCanvasArgsForCall)
 }
 
-func (fake *Parser) ParseFileCalls(stub func(context.Context, string) ([]*model.Link, error)) {
-	fake.parseFileMutex.Lock()
-	defer fake.parseFileMutex.Unlock()
-	fake.ParseFileStub = stub
+func (fake *Parser) ParseCanvasCalls(stub func(context.Context, string) ([]*model.Link, error)) {
+	fake.parseCanvasMutex.Lock()
+	defer fake.parseCanvasMutex.Unlock()
+	fake.ParseCanvasStub = stub
 }
 
-func (fake *Parser) ParseFileArgsForCall(i int) (context.Context, string) {
-	fake.parseFileMutex.RLock()
-	defer fake.parseFileMutex.RUnlock()
-	argsForCall := fake.parseFileArgsForCall[i]
+func (fake *Parser) ParseCanvasArgsForCall(i int) (context.Context, string) {
+	fake.parseCanvasMutex.RLock()
+	defer fake.parseCanvasMutex.RUnlock()
+	argsForCall := fake.parseCanvasArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *Parser) ParseFileReturns(result1 []*model.Link, result2 error) {
-	fake.parseFileMutex.Lock()
-	defer fake.parseFileMutex.Unlock()
-	fake.ParseFileStub = nil
-	fake.parseFileReturns = struct {
+func (fake *Parser) ParseCanvasReturns(result1 []*model.Link, result2 error) {
+	fake.parseCanvasMutex.Lock()
+	defer fake.parseCanvasMutex.Unlock()
+	fake.ParseCanvasStub = nil
+	fake.parseCanvasReturns = struct {
 		result1 []*model.Link
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *Parser) ParseFileReturnsOnCall(i int, result1 []*model.Link, result2 error) {
-	fake.parseFileMutex.Lock()
-	defer fake.parseFileMutex.Unlock()
-	fake.ParseFileStub = nil
-	if fake.parseFileReturnsOnCall == nil {
-		fake.parseFileReturnsOnCall = make(map[int]struct {
+func (fake *Parser) ParseCanvasReturnsOnCall(i int, result1 []*model.Link, result2 error) {
+	fake.parseCanvasMutex.Lock()
+	defer fake.parseCanvasMutex.Unlock()
+	fake.ParseCanvasStub = nil
+	if fake.parseCanvasReturnsOnCall == nil {
+		fake.parseCanvasReturnsOnCall = make(map[int]struct {
 			result1 []*model.Link
 			result2 error
 		})
 	}
-	fake.parseFileReturnsOnCall[i] = struct {
+	fake.parseCanvasReturnsOnCall[i] = struct {
 		result1 []*model.Link
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *Parser) ParseContent(arg1 context.Context, arg2 string, arg3 string) (*model.ParsedFile, error) {
+	fake.parseContentMutex.Lock()
+	ret, specificReturn := fake.parseContentReturnsOnCall[len(fake.parseContentArgsForCall)]
+	fake.parseContentArgsForCall = append(fake.parseContentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ParseContentStub
+	fakeReturns := fake.parseContentReturns
+	fake.recordInvocation("ParseContent", []interface{}{arg1, arg2, arg3})
+	fake.parseContentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Parser) ParseContentCallCount() int {
+	fake.parseContentMutex.RLock()
+	defer fake.parseContentMutex.RUnlock()
+	return len(fake.parseContentArgsForCall)
+}
+
+func (fake *Parser) ParseContentCalls(stub func(context.Context, string, string) (*model.ParsedFile, error)) {
+	fake.parseContentMutex.Lock()
+	defer fake.parseContentMutex.Unlock()
+	fake.ParseContentStub = stub
+}
+
+func (fake *Parser) ParseContentArgsForCall(i int) (context.Context, string, string) {
+	fake.parseContentMutex.RLock()
+	defer fake.parseContentMutex.RUnlock()
+	argsForCall := fake.parseContentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Parser) ParseContentReturns(result1 *model.ParsedFile, result2 error) {
+	fake.parseContentMutex.Lock()
+	defer fake.parseContentMutex.Unlock()
+	fake.ParseContentStub = nil
+	fake.parseContentReturns = struct {
+		result1 *model.ParsedFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Parser) ParseContentReturnsOnCall(i int, result1 *model.ParsedFile, result2 error) {
+	fake.parseContentMutex.Lock()
+	defer fake.parseContentMutex.Unlock()
+	fake.ParseContentStub = nil
+	if fake.parseContentReturnsOnCall == nil {
+		fake.parseContentReturnsOnCall = make(map[int]struct {
+			result1 *model.ParsedFile
+			result2 error
+		})
+	}
+	fake.parseContentReturnsOnCall[i] = struct {
+		result1 *model.ParsedFile
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Parser) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -11,7 +11,7 @@ import (
 )
 
 type Resolver struct {
-	ResolveStub        func(context.Context, *model.Link, *index.VaultIndex) bool
+	ResolveStub        func(context.Context, *model.Link, *index.VaultIndex) resolver.Result
 	resolveMutex       sync.RWMutex
 	resolveArgsForCall []struct {
 		arg1 context.Context
@@ -19,16 +19,16 @@ type Resolver struct {
 		arg3 *index.VaultIndex
 	}
 	resolveReturns struct {
-		result1 bool
+		result1 resolver.Result
 	}
 	resolveReturnsOnCall map[int]struct {
-		result1 bool
+		result1 resolver.Result
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Resolver) Resolve(arg1 context.Context, arg2 *model.Link, arg3 *index.VaultIndex) bool {
+func (fake *Resolver) Resolve(arg1 context.Context, arg2 *model.Link, arg3 *index.VaultIndex) resolver.Result {
 	fake.resolveMutex.Lock()
 	ret, specificReturn := fake.resolveReturnsOnCall[len(fake.resolveArgsForCall)]
 	fake.resolveArgsForCall = append(fake.resolveArgsForCall, struct {
@@ -55,7 +55,7 @@ func (fake *Resolver) ResolveCallCount() int {
 	return len(fake.resolveArgsForCall)
 }
 
-func (fake *Resolver) ResolveCalls(stub func(context.Context, *model.Link, *index.VaultIndex) bool) {
+func (fake *Resolver) ResolveCalls(stub func(context.Context, *model.Link, *index.VaultIndex) resolver.Result) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = stub
@@ -68,26 +68,26 @@ func (fake *Resolver) ResolveArgsForCall(i int) (context.Context, *model.Link, *
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *Resolver) ResolveReturns(result1 bool) {
+func (fake *Resolver) ResolveReturns(result1 resolver.Result) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = nil
 	fake.resolveReturns = struct {
-		result1 bool
+		result1 resolver.Result
 	}{result1}
 }
 
-func (fake *Resolver) ResolveReturnsOnCall(i int, result1 bool) {
+func (fake *Resolver) ResolveReturnsOnCall(i int, result1 resolver.Result) {
 	fake.resolveMutex.Lock()
 	defer fake.resolveMutex.Unlock()
 	fake.ResolveStub = nil
 	if fake.resolveReturnsOnCall == nil {
 		fake.resolveReturnsOnCall = make(map[int]struct {
-			result1 bool
+			result1 resolver.Result
 		})
 	}
 	fake.resolveReturnsOnCall[i] = struct {
-		result1 bool
+		result1 resolver.Result
 	}{result1}
 }
 
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+)
+
+type SchemaChecker struct {
+	CheckStub        func(context.Context, *model.ParsedFile, *index.VaultIndex) []model.BrokenLink
+	checkMutex       sync.RWMutex
+	checkArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.ParsedFile
+		arg3 *index.VaultIndex
+	}
+	checkReturns struct {
+		result1 []model.BrokenLink
+	}
+	checkReturnsOnCall map[int]struct {
+		result1 []model.BrokenLink
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SchemaChecker) Check(arg1 context.Context, arg2 *model.ParsedFile, arg3 *index.VaultIndex) []model.BrokenLink {
+	fake.checkMutex.Lock()
+	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
+	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.ParsedFile
+		arg3 *index.VaultIndex
+	}{arg1, arg2, arg3})
+	stub := fake.CheckStub
+	fakeReturns := fake.checkReturns
+	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3})
+	fake.checkMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SchemaChecker) CheckCallCount() int {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	return len(fake.checkArgsForCall)
+}
+
+func (fake *SchemaChecker) CheckCalls(stub func(context.Context, *model.ParsedFile, *index.VaultIndex) []model.BrokenLink) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = stub
+}
+
+func (fake *SchemaChecker) CheckArgsForCall(i int) (context.Context, *model.ParsedFile, *index.VaultIndex) {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	argsForCall := fake.checkArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SchemaChecker) CheckReturns(result1 []model.BrokenLink) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	fake.checkReturns = struct {
+		result1 []model.BrokenLink
+	}{result1}
+}
+
+func (fake *SchemaChecker) CheckReturnsOnCall(i int, result1 []model.BrokenLink) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	if fake.checkReturnsOnCall == nil {
+		fake.checkReturnsOnCall = make(map[int]struct {
+			result1 []model.BrokenLink
+		})
+	}
+	fake.checkReturnsOnCall[i] = struct {
+		result1 []model.BrokenLink
+	}{result1}
+}
+
+func (fake *SchemaChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SchemaChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ schema.Checker = new(SchemaChecker)
@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
+)
+
+type Suggester struct {
+	SuggestStub        func(context.Context, string, *index.VaultIndex) []suggest.Suggestion
+	suggestMutex       sync.RWMutex
+	suggestArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *index.VaultIndex
+	}
+	suggestReturns struct {
+		result1 []suggest.Suggestion
+	}
+	suggestReturnsOnCall map[int]struct {
+		result1 []suggest.Suggestion
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Suggester) Suggest(arg1 context.Context, arg2 string, arg3 *index.VaultIndex) []suggest.Suggestion {
+	fake.suggestMutex.Lock()
+	ret, specificReturn := fake.suggestReturnsOnCall[len(fake.suggestArgsForCall)]
+	fake.suggestArgsForCall = append(fake.suggestArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *index.VaultIndex
+	}{arg1, arg2, arg3})
+	stub := fake.SuggestStub
+	fakeReturns := fake.suggestReturns
+	fake.recordInvocation("Suggest", []interface{}{arg1, arg2, arg3})
+	fake.suggestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Suggester) SuggestCallCount() int {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	return len(fake.suggestArgsForCall)
+}
+
+func (fake *Suggester) SuggestCalls(stub func(context.Context, string, *index.VaultIndex) []suggest.Suggestion) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = stub
+}
+
+func (fake *Suggester) SuggestArgsForCall(i int) (context.Context, string, *index.VaultIndex) {
+	fake.suggestMutex.RLock()
+	defer fake.suggestMutex.RUnlock()
+	argsForCall := fake.suggestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Suggester) SuggestReturns(result1 []suggest.Suggestion) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	fake.suggestReturns = struct {
+		result1 []suggest.Suggestion
+	}{result1}
+}
+
+func (fake *Suggester) SuggestReturnsOnCall(i int, result1 []suggest.Suggestion) {
+	fake.suggestMutex.Lock()
+	defer fake.suggestMutex.Unlock()
+	fake.SuggestStub = nil
+	if fake.suggestReturnsOnCall == nil {
+		fake.suggestReturnsOnCall = make(map[int]struct {
+			result1 []suggest.Suggestion
+		})
+	}
+	fake.suggestReturnsOnCall[i] = struct {
+		result1 []suggest.Suggestion
+	}{result1}
+}
+
+func (fake *Suggester) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Suggester) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ suggest.Suggester = new(Suggester)
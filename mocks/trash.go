@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bborbe/obsidian-lint/pkg/trash"
+)
+
+type Trash struct {
+	MoveStub        func(context.Context, string, string) (string, error)
+	moveMutex       sync.RWMutex
+	moveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	moveReturns struct {
+		result1 string
+		result2 error
+	}
+	moveReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Trash) Move(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.moveMutex.Lock()
+	ret, specificReturn := fake.moveReturnsOnCall[len(fake.moveArgsForCall)]
+	fake.moveArgsForCall = append(fake.moveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.MoveStub
+	fakeReturns := fake.moveReturns
+	fake.recordInvocation("Move", []interface{}{arg1, arg2, arg3})
+	fake.moveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Trash) MoveCallCount() int {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	return len(fake.moveArgsForCall)
+}
+
+func (fake *Trash) MoveCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = stub
+}
+
+func (fake *Trash) MoveArgsForCall(i int) (context.Context, string, string) {
+	fake.moveMutex.RLock()
+	defer fake.moveMutex.RUnlock()
+	argsForCall := fake.moveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Trash) MoveReturns(result1 string, result2 error) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	fake.moveReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Trash) MoveReturnsOnCall(i int, result1 string, result2 error) {
+	fake.moveMutex.Lock()
+	defer fake.moveMutex.Unlock()
+	fake.MoveStub = nil
+	if fake.moveReturnsOnCall == nil {
+		fake.moveReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.moveReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Trash) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Trash) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ trash.Trash = new(Trash)
@@ -5,6 +5,8 @@ import (
 	"context"
 	"sync"
 
+	"github.com/bborbe/obsidian-lint/pkg/git"
+	"github.com/bborbe/obsidian-lint/pkg/index"
 	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/validator"
 )
@@ -24,6 +26,50 @@ type Validator struct {
 		result1 *model.ValidationResult
 		result2 error
 	}
+	ValidateDiffStub        func(context.Context, string, *git.Diff) (*model.ValidationResult, error)
+	validateDiffMutex       sync.RWMutex
+	validateDiffArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *git.Diff
+	}
+	validateDiffReturns struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
+	validateDiffReturnsOnCall map[int]struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
+	ValidateIndexStub        func(context.Context, *index.VaultIndex) (*model.ValidationResult, error)
+	validateIndexMutex       sync.RWMutex
+	validateIndexArgsForCall []struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+	}
+	validateIndexReturns struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
+	validateIndexReturnsOnCall map[int]struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
+	ValidateNotesStub        func(context.Context, *index.VaultIndex, []string) (*model.ValidationResult, error)
+	validateNotesMutex       sync.RWMutex
+	validateNotesArgsForCall []struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+		arg3 []string
+	}
+	validateNotesReturns struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
+	validateNotesReturnsOnCall map[int]struct {
+		result1 *model.ValidationResult
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -93,6 +139,208 @@ func (fake *Validator) ValidateReturnsOnCall(i int, result1 *model.ValidationRes
 	}{result1, result2}
 }
 
+func (fake *Validator) ValidateDiff(arg1 context.Context, arg2 string, arg3 *git.Diff) (*model.ValidationResult, error) {
+	fake.validateDiffMutex.Lock()
+	ret, specificReturn := fake.validateDiffReturnsOnCall[len(fake.validateDiffArgsForCall)]
+	fake.validateDiffArgsForCall = append(fake.validateDiffArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *git.Diff
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateDiffStub
+	fakeReturns := fake.validateDiffReturns
+	fake.recordInvocation("ValidateDiff", []interface{}{arg1, arg2, arg3})
+	fake.validateDiffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Validator) ValidateDiffCallCount() int {
+	fake.validateDiffMutex.RLock()
+	defer fake.validateDiffMutex.RUnlock()
+	return len(fake.validateDiffArgsForCall)
+}
+
+func (fake *Validator) ValidateDiffCalls(stub func(context.Context, string, *git.Diff) (*model.ValidationResult, error)) {
+	fake.validateDiffMutex.Lock()
+	defer fake.validateDiffMutex.Unlock()
+	fake.ValidateDiffStub = stub
+}
+
+func (fake *Validator) ValidateDiffArgsForCall(i int) (context.Context, string, *git.Diff) {
+	fake.validateDiffMutex.RLock()
+	defer fake.validateDiffMutex.RUnlock()
+	argsForCall := fake.validateDiffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Validator) ValidateDiffReturns(result1 *model.ValidationResult, result2 error) {
+	fake.validateDiffMutex.Lock()
+	defer fake.validateDiffMutex.Unlock()
+	fake.ValidateDiffStub = nil
+	fake.validateDiffReturns = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Validator) ValidateDiffReturnsOnCall(i int, result1 *model.ValidationResult, result2 error) {
+	fake.validateDiffMutex.Lock()
+	defer fake.validateDiffMutex.Unlock()
+	fake.ValidateDiffStub = nil
+	if fake.validateDiffReturnsOnCall == nil {
+		fake.validateDiffReturnsOnCall = make(map[int]struct {
+			result1 *model.ValidationResult
+			result2 error
+		})
+	}
+	fake.validateDiffReturnsOnCall[i] = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Validator) ValidateIndex(arg1 context.Context, arg2 *index.VaultIndex) (*model.ValidationResult, error) {
+	fake.validateIndexMutex.Lock()
+	ret, specificReturn := fake.validateIndexReturnsOnCall[len(fake.validateIndexArgsForCall)]
+	fake.validateIndexArgsForCall = append(fake.validateIndexArgsForCall, struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+	}{arg1, arg2})
+	stub := fake.ValidateIndexStub
+	fakeReturns := fake.validateIndexReturns
+	fake.recordInvocation("ValidateIndex", []interface{}{arg1, arg2})
+	fake.validateIndexMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Validator) ValidateIndexCallCount() int {
+	fake.validateIndexMutex.RLock()
+	defer fake.validateIndexMutex.RUnlock()
+	return len(fake.validateIndexArgsForCall)
+}
+
+func (fake *Validator) ValidateIndexCalls(stub func(context.Context, *index.VaultIndex) (*model.ValidationResult, error)) {
+	fake.validateIndexMutex.Lock()
+	defer fake.validateIndexMutex.Unlock()
+	fake.ValidateIndexStub = stub
+}
+
+func (fake *Validator) ValidateIndexArgsForCall(i int) (context.Context, *index.VaultIndex) {
+	fake.validateIndexMutex.RLock()
+	defer fake.validateIndexMutex.RUnlock()
+	argsForCall := fake.validateIndexArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Validator) ValidateIndexReturns(result1 *model.ValidationResult, result2 error) {
+	fake.validateIndexMutex.Lock()
+	defer fake.validateIndexMutex.Unlock()
+	fake.ValidateIndexStub = nil
+	fake.validateIndexReturns = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Validator) ValidateIndexReturnsOnCall(i int, result1 *model.ValidationResult, result2 error) {
+	fake.validateIndexMutex.Lock()
+	defer fake.validateIndexMutex.Unlock()
+	fake.ValidateIndexStub = nil
+	if fake.validateIndexReturnsOnCall == nil {
+		fake.validateIndexReturnsOnCall = make(map[int]struct {
+			result1 *model.ValidationResult
+			result2 error
+		})
+	}
+	fake.validateIndexReturnsOnCall[i] = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Validator) ValidateNotes(arg1 context.Context, arg2 *index.VaultIndex, arg3 []string) (*model.ValidationResult, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.validateNotesMutex.Lock()
+	ret, specificReturn := fake.validateNotesReturnsOnCall[len(fake.validateNotesArgsForCall)]
+	fake.validateNotesArgsForCall = append(fake.validateNotesArgsForCall, struct {
+		arg1 context.Context
+		arg2 *index.VaultIndex
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.ValidateNotesStub
+	fakeReturns := fake.validateNotesReturns
+	fake.recordInvocation("ValidateNotes", []interface{}{arg1, arg2, arg3Copy})
+	fake.validateNotesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Validator) ValidateNotesCallCount() int {
+	fake.validateNotesMutex.RLock()
+	defer fake.validateNotesMutex.RUnlock()
+	return len(fake.validateNotesArgsForCall)
+}
+
+func (fake *Validator) ValidateNotesCalls(stub func(context.Context, *index.VaultIndex, []string) (*model.ValidationResult, error)) {
+	fake.validateNotesMutex.Lock()
+	defer fake.validateNotesMutex.Unlock()
+	fake.ValidateNotesStub = stub
+}
+
+func (fake *Validator) ValidateNotesArgsForCall(i int) (context.Context, *index.VaultIndex, []string) {
+	fake.validateNotesMutex.RLock()
+	defer fake.validateNotesMutex.RUnlock()
+	argsForCall := fake.validateNotesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Validator) ValidateNotesReturns(result1 *model.ValidationResult, result2 error) {
+	fake.validateNotesMutex.Lock()
+	defer fake.validateNotesMutex.Unlock()
+	fake.ValidateNotesStub = nil
+	fake.validateNotesReturns = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Validator) ValidateNotesReturnsOnCall(i int, result1 *model.ValidationResult, result2 error) {
+	fake.validateNotesMutex.Lock()
+	defer fake.validateNotesMutex.Unlock()
+	fake.ValidateNotesStub = nil
+	if fake.validateNotesReturnsOnCall == nil {
+		fake.validateNotesReturnsOnCall = make(map[int]struct {
+			result1 *model.ValidationResult
+			result2 error
+		})
+	}
+	fake.validateNotesReturnsOnCall[i] = struct {
+		result1 *model.ValidationResult
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Validator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
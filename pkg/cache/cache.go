@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// Dir is the cache folder created in the vault root
+const Dir = ".obsidian-lint-cache"
+
+// FileName is the name of the cache file inside Dir
+const FileName = "parsed.json"
+
+// Version is the cache format version. Increase it whenever the parser output
+// changes, so caches written by older releases are discarded.
+const Version = 10
+
+//counterfeiter:generate -o ../../mocks/cache_store.go --fake-name CacheStore . Store
+
+// Store keeps parsed notes between runs
+type Store interface {
+	Get(path string) (Entry, bool)
+	Put(path string, entry Entry)
+	// Save writes all entries read or written since Load, entries of deleted
+	// notes are dropped
+	Save(ctx context.Context) error
+}
+
+// Entry is a parsed note together with the file state it was parsed from
+type Entry struct {
+	Size    int64             `json:"size"`
+	ModTime int64             `json:"modTime"` // unix nanoseconds
+	Hash    string            `json:"hash"`    // hex sha256 of the content
+	Parsed  *model.ParsedFile `json:"parsed"`
+}
+
+// cacheFile is the content of FileName
+type cacheFile struct {
+	Version string           `json:"version"`
+	Files   map[string]Entry `json:"files"`
+}
+
+// Load reads the cache of the vault. A missing, corrupt or outdated cache
+// results in an empty store.
+func Load(ctx context.Context, vaultPath string) (Store, error) {
+	s := &store{
+		dir:     filepath.Join(vaultPath, Dir),
+		entries: make(map[string]Entry),
+		used:    make(map[string]struct{}),
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.dir, FileName))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read cache failed")
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(content, &file); err != nil || file.Version != version() {
+		s.dirty = true
+		return s, nil
+	}
+	if file.Files != nil {
+		s.entries = file.Files
+	}
+
+	return s, nil
+}
+
+type store struct {
+	dir     string
+	mux     sync.Mutex
+	entries map[string]Entry
+	used    map[string]struct{}
+	dirty   bool
+}
+
+// Get returns the entry of path
+func (s *store) Get(path string) (Entry, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	entry, exists := s.entries[path]
+	if exists {
+		s.used[path] = struct{}{}
+	}
+	return entry, exists
+}
+
+// Put replaces the entry of path
+func (s *store) Put(path string, entry Entry) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.entries[path] = entry
+	s.used[path] = struct{}{}
+	s.dirty = true
+}
+
+// Save writes the cache file atomically, unless nothing changed
+func (s *store) Save(ctx context.Context) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	file := cacheFile{
+		Version: version(),
+		Files:   make(map[string]Entry, len(s.used)),
+	}
+	for path := range s.used {
+		file.Files[path] = s.entries[path]
+	}
+	if !s.dirty && len(file.Files) == len(s.entries) {
+		return nil
+	}
+
+	content, err := json.Marshal(file)
+	if err != nil {
+		return errors.Wrap(ctx, err, "marshal cache failed")
+	}
+	if err := os.MkdirAll(s.dir, 0750); err != nil {
+		return errors.Wrap(ctx, err, "create cache folder failed")
+	}
+	// Keep the cache out of git if the vault is a repository
+	if err := os.WriteFile(filepath.Join(s.dir, ".gitignore"), []byte("*\n"), 0600); err != nil {
+		return errors.Wrap(ctx, err, "write cache gitignore failed")
+	}
+
+	tmp, err := os.CreateTemp(s.dir, FileName+".*")
+	if err != nil {
+		return errors.Wrap(ctx, err, "create cache file failed")
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(ctx, err, "write cache failed")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(ctx, err, "close cache failed")
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, FileName)); err != nil {
+		return errors.Wrap(ctx, err, "replace cache failed")
+	}
+
+	s.entries = file.Files
+	s.dirty = false
+	return nil
+}
+
+// version identifies the cache format and the linter build that wrote the cache
+func version() string {
+	build := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		build = info.Main.Version
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				build += "+" + setting.Value
+			}
+		}
+	}
+	return fmt.Sprintf("%d/%s", Version, build)
+}
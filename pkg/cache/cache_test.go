@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/mocks"
+	"github.com/bborbe/obsidian-lint/pkg/cache"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		ctx     context.Context
+		tempDir string
+		note    string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir, err = os.MkdirTemp("", "cache-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		note = filepath.Join(tempDir, "Note.md")
+		Expect(os.WriteFile(note, []byte("# Title\n[[Other]]"), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	// parse parses note with a caching parser backed by a freshly loaded store
+	// and returns how often the wrapped parser was called. The wrapped parser gets
+	// the content read for hashing and never reads the note itself.
+	parse := func() (*model.ParsedFile, int) {
+		store, err := cache.Load(ctx, tempDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		fake := &mocks.Parser{}
+		fake.ParseStub = parser.New().Parse
+		fake.ParseContentStub = parser.New().ParseContent
+		parsed, err := cache.NewParser(fake, store).Parse(ctx, note)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store.Save(ctx)).To(Succeed())
+		Expect(fake.ParseCallCount()).To(Equal(0))
+		return parsed, fake.ParseContentCallCount()
+	}
+
+	Context("Parser", func() {
+		It("parses a note once and reuses the result in later runs", func() {
+			parsed, calls := parse()
+			Expect(calls).To(Equal(1))
+			Expect(parsed.Headings).To(Equal([]string{"Title"}))
+			Expect(filepath.Join(tempDir, cache.Dir, cache.FileName)).To(BeAnExistingFile())
+
+			parsed, calls = parse()
+			Expect(calls).To(Equal(0))
+			Expect(parsed.Path).To(Equal(note))
+			Expect(parsed.Headings).To(Equal([]string{"Title"}))
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Other"))
+		})
+
+		It("parses a changed note again", func() {
+			parse()
+			Expect(os.WriteFile(note, []byte("# Changed\n"), 0600)).To(Succeed())
+			later := time.Now().Add(time.Minute)
+			Expect(os.Chtimes(note, later, later)).To(Succeed())
+
+			parsed, calls := parse()
+			Expect(calls).To(Equal(1))
+			Expect(parsed.Headings).To(Equal([]string{"Changed"}))
+		})
+
+		It("reuses a touched note with unchanged content", func() {
+			parse()
+			later := time.Now().Add(time.Minute)
+			Expect(os.Chtimes(note, later, later)).To(Succeed())
+
+			_, calls := parse()
+			Expect(calls).To(Equal(0))
+		})
+
+		It("passes errors of missing notes through", func() {
+			store, err := cache.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = cache.NewParser(parser.New(), store).
+				Parse(ctx, filepath.Join(tempDir, "missing.md"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Load", func() {
+		It("discards a cache written by another version", func() {
+			parse()
+			cacheFile := filepath.Join(tempDir, cache.Dir, cache.FileName)
+			content := `{"version":"0/old","files":{"` + note + `":{"size":17}}}`
+			Expect(os.WriteFile(cacheFile, []byte(content), 0600)).To(Succeed())
+
+			store, err := cache.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			_, exists := store.Get(note)
+			Expect(exists).To(BeFalse())
+		})
+
+		It("ignores a corrupt cache", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, cache.Dir), 0750)).To(Succeed())
+			cacheFile := filepath.Join(tempDir, cache.Dir, cache.FileName)
+			Expect(os.WriteFile(cacheFile, []byte("{"), 0600)).To(Succeed())
+
+			_, calls := parse()
+			Expect(calls).To(Equal(1))
+		})
+	})
+
+	Context("Save", func() {
+		It("drops entries of notes not parsed in the run", func() {
+			parse()
+
+			store, err := cache.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Save(ctx)).To(Succeed())
+
+			store, err = cache.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			_, exists := store.Get(note)
+			Expect(exists).To(BeFalse())
+		})
+
+		It("keeps the cache out of git", func() {
+			parse()
+
+			content, err := os.ReadFile(filepath.Join(tempDir, cache.Dir, ".gitignore"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("*\n"))
+		})
+	})
+})
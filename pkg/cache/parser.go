@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+)
+
+// NewParser creates a Parser that returns cached results of Parse for notes
+// unchanged since they were stored. All other methods are passed through.
+func NewParser(parser parser.Parser, store Store) parser.Parser {
+	return &cachingParser{
+		Parser: parser,
+		store:  store,
+	}
+}
+
+type cachingParser struct {
+	parser.Parser
+	store Store
+}
+
+// Parse treats a note as unchanged if size and modification time match. Otherwise
+// the content hash is compared, so touched but unchanged notes (git checkout) are
+// not parsed again. Changed notes are parsed from the hashed bytes, so the cached
+// result always belongs to its hash. The modification time is taken before reading,
+// a note changed meanwhile is compared by hash again next time.
+func (c *cachingParser) Parse(ctx context.Context, filePath string) (*model.ParsedFile, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return c.Parser.Parse(ctx, filePath)
+	}
+
+	entry, exists := c.store.Get(filePath)
+	modTime := info.ModTime().UnixNano()
+	if exists && entry.Size == info.Size() && entry.ModTime == modTime {
+		return entry.Parsed, nil
+	}
+
+	// #nosec G304 -- filePath comes from scanner.Scan(), not user input
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return c.Parser.Parse(ctx, filePath)
+	}
+	size := int64(len(content))
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	if exists && entry.Size == size && entry.Hash == hash {
+		entry.ModTime = modTime
+		c.store.Put(filePath, entry)
+		return entry.Parsed, nil
+	}
+
+	parsed, err := c.Parser.ParseContent(ctx, filePath, string(content))
+	if err != nil {
+		return nil, err
+	}
+	c.store.Put(filePath, Entry{
+		Size:    size,
+		ModTime: modTime,
+		Hash:    hash,
+		Parsed:  parsed,
+	})
+	return parsed, nil
+}
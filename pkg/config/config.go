@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// FileName is the name of the config file discovered in the vault root
+const FileName = ".obsidian-lint.yaml"
+
+// Version is the config file format version supported by this release
+const Version = 1
+
+//counterfeiter:generate -o ../../mocks/config_loader.go --fake-name ConfigLoader . Loader
+
+// Loader reads the linter configuration
+type Loader interface {
+	// Load reads configPath, or FileName in the vault root if configPath is empty.
+	// Without a config file the default configuration is returned.
+	Load(ctx context.Context, vaultPath string, configPath string) (*Config, error)
+}
+
+// Config contains the linter configuration
+type Config struct {
+	Version        int                 `yaml:"version"`
+	Format         string              `yaml:"format"`         // output format
+	PreferClosest  bool                `yaml:"preferClosest"`  // resolve ambiguous links like Obsidian
+	Ignore         []string            `yaml:"ignore"`         // glob patterns of vault paths to skip
+	AllowedTargets []string            `yaml:"allowedTargets"` // glob patterns of link targets allowed to dangle
+	Rules          map[model.Kind]Rule `yaml:"rules"`          // per check settings
+	Orphans        Orphans             `yaml:"orphans"`        // orphan-note settings
+	Attachments    Attachments         `yaml:"attachments"`    // unused-attachment settings
+	LinkFormat     model.LinkFormat    `yaml:"linkFormat"`     // form of fixed and moved links, default from app.json
+	Suggestions    int                 `yaml:"suggestions"`    // number of "did you mean" targets per broken link
+	Workers        int                 `yaml:"workers"`        // files parsed in parallel, 0 for one per CPU
+	Schemas        []Schema            `yaml:"schemas"`        // frontmatter schemas by folder or note type
+}
+
+// Schema describes the frontmatter of the notes in some folders or of a note type.
+// It applies to notes matching one of the paths or with the type property set to Type.
+type Schema struct {
+	Paths      []string            `yaml:"paths"`      // glob patterns of vault paths
+	Type       string              `yaml:"type"`       // value of the type property
+	Properties map[string]Property `yaml:"properties"` // property definitions by name
+}
+
+// PropertyType is the value type of a frontmatter property
+type PropertyType string
+
+const (
+	// PropertyTypeString is text, unquoted dates count as text
+	PropertyTypeString PropertyType = "string"
+	// PropertyTypeNumber is an integer or decimal number
+	PropertyTypeNumber PropertyType = "number"
+	// PropertyTypeInteger is a whole number
+	PropertyTypeInteger PropertyType = "integer"
+	// PropertyTypeBoolean is true or false, a checkbox in Obsidian
+	PropertyTypeBoolean PropertyType = "boolean"
+	// PropertyTypeDate is a date in Format, YYYY-MM-DD by default
+	PropertyTypeDate PropertyType = "date"
+	// PropertyTypeDateTime is a date and time in Format, YYYY-MM-DDTHH:mm[:ss] by default
+	PropertyTypeDateTime PropertyType = "datetime"
+	// PropertyTypeLink is a wiki link like "[[Note]]", its target is checked like all links
+	PropertyTypeLink PropertyType = "link"
+	// PropertyTypeList is a list of values
+	PropertyTypeList PropertyType = "list"
+)
+
+// Property describes a frontmatter property of a schema
+type Property struct {
+	Type     PropertyType `yaml:"type"`     // any type if empty
+	Required bool         `yaml:"required"` // property must exist and not be empty
+	Enum     []string     `yaml:"enum"`     // allowed values, for lists of each item
+	Format   string       `yaml:"format"`   // date format like YYYY-MM-DD for date and datetime
+	Items    PropertyType `yaml:"items"`    // type of list items
+}
+
+// Orphans configures which notes may have no incoming links
+type Orphans struct {
+	Ignore []string `yaml:"ignore"` // glob patterns of vault paths never reported as orphans
+	Tags   []string `yaml:"tags"`   // notes with one of these tags are entry points, e.g. root
+}
+
+// Attachments configures which files are attachments for the unused-attachment check
+type Attachments struct {
+	Folder string `yaml:"folder"` // all files in this vault folder, default from app.json
+}
+
+// Rule configures a single check
+type Rule struct {
+	Severity model.Severity `yaml:"severity"` // error, warning or off
+}
+
+// Default returns the configuration used without a config file
+func Default() *Config {
+	return &Config{
+		Version:     Version,
+		Format:      "text",
+		Suggestions: 3,
+	}
+}
+
+// defaultSeverities lists the severity of every check not configured in rules
+var defaultSeverities = map[model.Kind]model.Severity{
+	model.KindBrokenLink:           model.SeverityError,
+	model.KindBrokenHeading:        model.SeverityError,
+	model.KindBrokenBlock:          model.SeverityError,
+	model.KindAmbiguousLink:        model.SeverityWarning,
+	model.KindUnusedSuppression:    model.SeverityWarning,
+	model.KindOrphanNote:           model.SeverityOff,
+	model.KindUnusedAttachment:     model.SeverityOff,
+	model.KindMalformedFrontmatter: model.SeverityWarning,
+	model.KindSchemaViolation:      model.SeverityError,
+}
+
+// Severity returns the configured severity of a check
+func (c *Config) Severity(kind model.Kind) model.Severity {
+	if rule, exists := c.Rules[kind]; exists && rule.Severity != "" {
+		return rule.Severity
+	}
+	if severity, exists := defaultSeverities[kind]; exists {
+		return severity
+	}
+	return model.SeverityError
+}
+
+// Severities returns the configured severity of every check
+func (c *Config) Severities() map[model.Kind]model.Severity {
+	result := make(map[model.Kind]model.Severity, len(defaultSeverities))
+	for kind := range defaultSeverities {
+		result[kind] = c.Severity(kind)
+	}
+	return result
+}
+
+// Enabled returns false if the check is switched off
+func (c *Config) Enabled(kind model.Kind) bool {
+	return c.Severity(kind) != model.SeverityOff
+}
+
+// IsRootTag returns true if tag marks a note as entry point that needs no incoming links
+func (c *Config) IsRootTag(tag string) bool {
+	for _, rootTag := range c.Orphans.Tags {
+		if strings.EqualFold(strings.TrimPrefix(rootTag, "#"), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAllowedTarget returns true if a missing link target may dangle (case-insensitive)
+func (c *Config) IsAllowedTarget(target string) bool {
+	target = strings.ToLower(strings.TrimSuffix(target, ".md"))
+	for _, pattern := range c.AllowedTargets {
+		pattern = strings.ToLower(strings.TrimSuffix(pattern, ".md"))
+		if matched, err := path.Match(pattern, target); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the configuration for unsupported values
+func (c *Config) Validate(ctx context.Context) error {
+	if c.Version != Version {
+		return errors.Errorf(ctx, "unsupported config version %d (must be %d)", c.Version, Version)
+	}
+	if c.Suggestions < 0 {
+		return errors.Errorf(ctx, "invalid suggestions %d (must not be negative)", c.Suggestions)
+	}
+	if c.Workers < 0 {
+		return errors.Errorf(ctx, "invalid workers %d (must not be negative)", c.Workers)
+	}
+	switch c.LinkFormat {
+	case "", model.LinkFormatShortest, model.LinkFormatRelative, model.LinkFormatAbsolute:
+	default:
+		return errors.Errorf(
+			ctx,
+			"invalid link format %q (must be shortest, relative or absolute)",
+			c.LinkFormat,
+		)
+	}
+	for i, schema := range c.Schemas {
+		if err := schema.Validate(ctx); err != nil {
+			return errors.Wrapf(ctx, err, "invalid schema %d", i+1)
+		}
+	}
+	for kind, rule := range c.Rules {
+		if _, exists := defaultSeverities[kind]; !exists {
+			return errors.Errorf(
+				ctx,
+				"unknown rule %s (must be one of %s)",
+				kind,
+				strings.Join(knownRules(), ", "),
+			)
+		}
+		switch rule.Severity {
+		case model.SeverityError, model.SeverityWarning, model.SeverityOff:
+		default:
+			return errors.Errorf(
+				ctx,
+				"invalid severity %q for rule %s (must be error, warning or off)",
+				rule.Severity,
+				kind,
+			)
+		}
+	}
+	return nil
+}
+
+// knownRules returns the names of all checks in alphabetical order
+func knownRules() []string {
+	names := make([]string, 0, len(defaultSeverities))
+	for kind := range defaultSeverities {
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks the schema for unsupported values
+func (s Schema) Validate(ctx context.Context) error {
+	if len(s.Paths) == 0 && s.Type == "" {
+		return errors.Errorf(ctx, "schema needs paths or type")
+	}
+	for name, property := range s.Properties {
+		if !validPropertyType(property.Type) {
+			return errors.Errorf(ctx, "invalid type %q of property %s", property.Type, name)
+		}
+		if property.Items != "" &&
+			(property.Type != PropertyTypeList || !validPropertyType(property.Items) ||
+				property.Items == PropertyTypeList) {
+			return errors.Errorf(ctx, "invalid items %q of property %s", property.Items, name)
+		}
+		if property.Format != "" && property.Type != PropertyTypeDate &&
+			property.Type != PropertyTypeDateTime && property.Items != PropertyTypeDate &&
+			property.Items != PropertyTypeDateTime {
+			return errors.Errorf(ctx, "format of property %s needs type date or datetime", name)
+		}
+	}
+	return nil
+}
+
+// validPropertyType returns true for known types and the empty type
+func validPropertyType(propertyType PropertyType) bool {
+	switch propertyType {
+	case "", PropertyTypeString, PropertyTypeNumber, PropertyTypeInteger, PropertyTypeBoolean,
+		PropertyTypeDate, PropertyTypeDateTime, PropertyTypeLink, PropertyTypeList:
+		return true
+	default:
+		return false
+	}
+}
+
+// New creates a new Loader
+func New() Loader {
+	return &loader{}
+}
+
+type loader struct{}
+
+// Load reads and validates the config file
+func (l *loader) Load(ctx context.Context, vaultPath string, configPath string) (*Config, error) {
+	explicit := configPath != ""
+	if !explicit {
+		configPath = filepath.Join(vaultPath, FileName)
+	}
+
+	// #nosec G304 -- config path is given by the user or built from the vault directory
+	content, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) && !explicit {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read config failed")
+	}
+
+	cfg := Default()
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrapf(ctx, err, "parse config %s failed", configPath)
+	}
+
+	if err := cfg.Validate(ctx); err != nil {
+		return nil, errors.Wrapf(ctx, err, "invalid config %s", configPath)
+	}
+
+	return cfg, nil
+}
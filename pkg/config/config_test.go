@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		ctx     context.Context
+		l       config.Loader
+		tempDir string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		l = config.New()
+
+		tempDir, err = os.MkdirTemp("", "config-test")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	writeConfig := func(content string) string {
+		file := filepath.Join(tempDir, config.FileName)
+		Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+		return file
+	}
+
+	Context("Load", func() {
+		It("returns default config without config file", func() {
+			cfg, err := l.Load(ctx, tempDir, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(config.Default()))
+		})
+
+		It("discovers config file in vault root", func() {
+			writeConfig(`version: 1
+format: json
+preferClosest: true
+ignore:
+  - Templates
+allowedTargets:
+  - "Ideas/*"
+rules:
+  broken-heading:
+    severity: warning
+  ambiguous-link:
+    severity: "off"
+`)
+
+			cfg, err := l.Load(ctx, tempDir, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Format).To(Equal("json"))
+			Expect(cfg.PreferClosest).To(BeTrue())
+			Expect(cfg.Ignore).To(Equal([]string{"Templates"}))
+			Expect(cfg.AllowedTargets).To(Equal([]string{"Ideas/*"}))
+			Expect(cfg.Severity(model.KindBrokenHeading)).To(Equal(model.SeverityWarning))
+			Expect(cfg.Enabled(model.KindAmbiguousLink)).To(BeFalse())
+		})
+
+		It("keeps defaults for an empty config file", func() {
+			writeConfig("")
+
+			cfg, err := l.Load(ctx, tempDir, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg).To(Equal(config.Default()))
+		})
+
+		It("reads explicit config path", func() {
+			file := filepath.Join(tempDir, "custom.yaml")
+			Expect(os.WriteFile(file, []byte("format: json\n"), 0600)).To(Succeed())
+
+			cfg, err := l.Load(ctx, tempDir, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Format).To(Equal("json"))
+		})
+
+		It("returns error for missing explicit config path", func() {
+			_, err := l.Load(ctx, tempDir, filepath.Join(tempDir, "missing.yaml"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for unknown fields", func() {
+			writeConfig("formatt: json\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for unsupported version", func() {
+			writeConfig("version: 2\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for invalid severity", func() {
+			writeConfig("rules:\n  broken-link:\n    severity: fatal\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for unknown rule", func() {
+			writeConfig("rules:\n  broken-headings:\n    severity: warning\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(MatchError(ContainSubstring("unknown rule broken-headings")))
+		})
+
+		It("returns error for negative suggestions", func() {
+			writeConfig("suggestions: -1\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for invalid link format", func() {
+			writeConfig("linkFormat: full\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error for negative workers", func() {
+			writeConfig("workers: -2\n")
+
+			_, err := l.Load(ctx, tempDir, "")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("reads schemas", func() {
+			writeConfig(`schemas:
+  - paths: [Projects]
+    properties:
+      status: {type: string, required: true, enum: [active, done]}
+      due: {type: date, format: DD.MM.YYYY}
+`)
+
+			cfg, err := l.Load(ctx, tempDir, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Schemas).To(HaveLen(1))
+			Expect(cfg.Schemas[0].Paths).To(Equal([]string{"Projects"}))
+			Expect(cfg.Schemas[0].Properties["status"]).To(Equal(config.Property{
+				Type:     config.PropertyTypeString,
+				Required: true,
+				Enum:     []string{"active", "done"},
+			}))
+			Expect(cfg.Schemas[0].Properties["due"].Format).To(Equal("DD.MM.YYYY"))
+		})
+
+		DescribeTable("returns error for invalid schemas",
+			func(schema string) {
+				writeConfig("schemas:\n  - " + schema + "\n")
+
+				_, err := l.Load(ctx, tempDir, "")
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("without paths and type", "properties: {status: {type: string}}"),
+			Entry("unknown type", "{type: project, properties: {status: {type: text}}}"),
+			Entry("items without list", "{type: project, properties: {tags: {items: string}}}"),
+			Entry("list of lists", "{type: project, properties: {a: {type: list, items: list}}}"),
+			Entry("format without date", "{type: project, properties: {a: {format: YYYY}}}"),
+		)
+	})
+
+	Context("Severity", func() {
+		It("uses default severities", func() {
+			cfg := config.Default()
+
+			Expect(cfg.Severity(model.KindBrokenLink)).To(Equal(model.SeverityError))
+			Expect(cfg.Severity(model.KindAmbiguousLink)).To(Equal(model.SeverityWarning))
+			Expect(cfg.Enabled(model.KindBrokenLink)).To(BeTrue())
+		})
+
+		It("returns the severity of every check", func() {
+			cfg := config.Default()
+			cfg.Rules = map[model.Kind]config.Rule{
+				model.KindOrphanNote: {Severity: model.SeverityWarning},
+			}
+
+			severities := cfg.Severities()
+			Expect(severities).To(HaveKeyWithValue(model.KindBrokenLink, model.SeverityError))
+			Expect(severities).To(HaveKeyWithValue(model.KindOrphanNote, model.SeverityWarning))
+			Expect(severities).To(HaveKeyWithValue(model.KindUnusedAttachment, model.SeverityOff))
+		})
+	})
+
+	Context("IsAllowedTarget", func() {
+		It("matches glob patterns case-insensitively", func() {
+			cfg := &config.Config{AllowedTargets: []string{"Ideas/*", "TODO.md"}}
+
+			Expect(cfg.IsAllowedTarget("ideas/New Plan")).To(BeTrue())
+			Expect(cfg.IsAllowedTarget("Todo")).To(BeTrue())
+			Expect(cfg.IsAllowedTarget("Other")).To(BeFalse())
+		})
+	})
+})
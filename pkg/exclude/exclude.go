@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exclude
+
+import (
+	"regexp"
+	"strings"
+)
+
+//counterfeiter:generate -o ../../mocks/exclude_matcher.go --fake-name ExcludeMatcher . Matcher
+
+// Matcher decides which vault paths are skipped when scanning and indexing
+type Matcher interface {
+	// Match returns true if the vault-relative path (with forward slashes) is excluded
+	Match(relPath string) bool
+}
+
+// New creates a Matcher from Obsidian excluded-file filters and glob patterns.
+// Filters are path prefixes like "Archive/" or regular expressions like "/\.excalidraw/",
+// invalid regular expressions are ignored like Obsidian does. Globs support *, ? and **,
+// a glob without / matches any single path segment.
+// Hidden files and folders (.obsidian, .trash, .git, ...) are always excluded.
+func New(filters []string, globs []string) Matcher {
+	m := &matcher{}
+	for _, filter := range filters {
+		if len(filter) > 2 && strings.HasPrefix(filter, "/") && strings.HasSuffix(filter, "/") {
+			if re, err := regexp.Compile(filter[1 : len(filter)-1]); err == nil {
+				m.regexps = append(m.regexps, re)
+			}
+			continue
+		}
+		if filter != "" {
+			m.prefixes = append(m.prefixes, filter)
+		}
+	}
+	for _, glob := range globs {
+		if glob = strings.Trim(strings.TrimSpace(glob), "/"); glob != "" {
+			m.globs = append(m.globs, globToRegexp(glob))
+		}
+	}
+	return m
+}
+
+type matcher struct {
+	prefixes []string
+	regexps  []*regexp.Regexp
+	globs    []*regexp.Regexp
+}
+
+// Match returns true if relPath or one of its parent folders is excluded
+func (m *matcher) Match(relPath string) bool {
+	segments := strings.Split(relPath, "/")
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, ".") && segment != "." && segment != ".." {
+			return true
+		}
+	}
+
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(relPath, prefix) || relPath+"/" == prefix {
+			return true
+		}
+	}
+
+	for _, re := range m.regexps {
+		if re.MatchString(relPath) {
+			return true
+		}
+	}
+
+	// Globs match the path itself or any of its parent folders
+	for i := range segments {
+		parent := strings.Join(segments[:i+1], "/")
+		for _, glob := range m.globs {
+			if glob.MatchString(parent) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// globToRegexp converts a glob pattern into an anchored regular expression
+func globToRegexp(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		// Match the segment in any folder
+		sb.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exclude_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+)
+
+var _ = Describe("Matcher", func() {
+	Context("Match", func() {
+		It("always excludes hidden files and folders", func() {
+			m := exclude.New(nil, nil)
+
+			Expect(m.Match(".obsidian")).To(BeTrue())
+			Expect(m.Match(".obsidian/app.json")).To(BeTrue())
+			Expect(m.Match(".trash/Old.md")).To(BeTrue())
+			Expect(m.Match(".git")).To(BeTrue())
+			Expect(m.Match("Notes/.hidden.md")).To(BeTrue())
+			Expect(m.Match("Notes/Visible.md")).To(BeFalse())
+		})
+
+		It("excludes Obsidian path prefix filters", func() {
+			m := exclude.New([]string{"Archive/", "Templates"}, nil)
+
+			Expect(m.Match("Archive")).To(BeTrue())
+			Expect(m.Match("Archive/2023/Note.md")).To(BeTrue())
+			Expect(m.Match("Templates/Daily.md")).To(BeTrue())
+			Expect(m.Match("Notes/Archive/Note.md")).To(BeFalse())
+		})
+
+		It("excludes Obsidian regex filters and ignores invalid ones", func() {
+			m := exclude.New([]string{`/\.excalidraw\.md$/`, "/[invalid/"}, nil)
+
+			Expect(m.Match("Drawings/Sketch.excalidraw.md")).To(BeTrue())
+			Expect(m.Match("Drawings/Sketch.md")).To(BeFalse())
+		})
+
+		It("excludes glob patterns", func() {
+			m := exclude.New(nil, []string{"Daily/*.md", "**/drafts", "*.tmp", " "})
+
+			Expect(m.Match("Daily/2025-01-01.md")).To(BeTrue())
+			Expect(m.Match("Daily/Sub/2025-01-01.md")).To(BeFalse())
+			Expect(m.Match("drafts/Idea.md")).To(BeTrue())
+			Expect(m.Match("Projects/drafts/Idea.md")).To(BeTrue())
+			Expect(m.Match("Projects/scratch.tmp")).To(BeTrue())
+			Expect(m.Match("Projects/Plan.md")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+//counterfeiter:generate -o ../../mocks/fixer.go --fake-name Fixer . Fixer
+
+// Fixer rewrites broken links that have a fix
+type Fixer interface {
+	// Plan computes the changed content of every file with fixable findings
+	Plan(ctx context.Context, result *model.ValidationResult) ([]Change, error)
+	// Apply writes the changed files
+	Apply(ctx context.Context, changes []Change) error
+}
+
+// Change is the fixed content of a single file
+type Change struct {
+	File     string
+	Original string
+	Fixed    string
+	Edits    []Edit
+}
+
+// Edit is a single rewritten link
+type Edit struct {
+	Line   int
+	Offset int // byte offset of Old in the original content
+	Old    string
+	New    string
+}
+
+// New creates a new Fixer
+func New() Fixer {
+	return &fixer{}
+}
+
+type fixer struct{}
+
+// Plan replaces each broken link with its fix at its byte offset. Links that
+// are no longer at their offset, because the file changed since validation, are
+// skipped. Files are returned sorted by path.
+func (f *fixer) Plan(ctx context.Context, result *model.ValidationResult) ([]Change, error) {
+	files := make([]string, 0, len(result.BrokenLinks))
+	for file := range result.BrokenLinks {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var changes []Change
+	for _, file := range files {
+		// #nosec G304 -- file paths come from the validation result, not user input
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "read file failed")
+		}
+
+		change := Change{File: file, Original: string(content)}
+		for _, brokenLink := range result.BrokenLinks[file] {
+			if brokenLink.Fix == "" || brokenLink.EndOffset > len(change.Original) ||
+				brokenLink.EndOffset <= brokenLink.Offset ||
+				change.Original[brokenLink.Offset:brokenLink.EndOffset] != brokenLink.Link {
+				continue
+			}
+			change.Edits = append(change.Edits, Edit{
+				Line:   brokenLink.Line,
+				Offset: brokenLink.Offset,
+				Old:    brokenLink.Link,
+				New:    brokenLink.Fix,
+			})
+		}
+		if len(change.Edits) == 0 {
+			continue
+		}
+
+		change.Fixed = Replace(change.Original, change.Edits)
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// Replace applies edits to content. Edits are sorted by offset and must not overlap.
+func Replace(content string, edits []Edit) string {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Offset < edits[j].Offset
+	})
+	var sb strings.Builder
+	pos := 0
+	for _, edit := range edits {
+		sb.WriteString(content[pos:edit.Offset])
+		sb.WriteString(edit.New)
+		pos = edit.Offset + len(edit.Old)
+	}
+	sb.WriteString(content[pos:])
+	return sb.String()
+}
+
+// Apply writes all changes, keeping the file permissions
+func (f *fixer) Apply(ctx context.Context, changes []Change) error {
+	for _, change := range changes {
+		info, err := os.Stat(change.File)
+		if err != nil {
+			return errors.Wrap(ctx, err, "stat file failed")
+		}
+		if err := os.WriteFile(change.File, []byte(change.Fixed), info.Mode().Perm()); err != nil {
+			return errors.Wrap(ctx, err, "write file failed")
+		}
+	}
+	return nil
+}
+
+// Diff returns a unified diff of a change with three lines of context. Fixes
+// only replace lines, so both sides always have the same number of lines.
+func Diff(change Change, name string) string {
+	// A trailing newline ends the last line and does not start another one
+	original := strings.Split(strings.TrimSuffix(change.Original, "\n"), "\n")
+	fixed := strings.Split(strings.TrimSuffix(change.Fixed, "\n"), "\n")
+
+	var changed []int
+	for i := range original {
+		if original[i] != fixed[i] {
+			changed = append(changed, i)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", name, name))
+
+	const contextLines = 3
+	for i := 0; i < len(changed); {
+		// Merge changes whose context lines overlap into one hunk
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*contextLines {
+			j++
+		}
+		start := max(changed[i]-contextLines, 0)
+		end := min(changed[j]+contextLines+1, len(original))
+
+		sb.WriteString(fmt.Sprintf(
+			"@@ -%d,%d +%d,%d @@\n",
+			start+1, end-start, start+1, end-start,
+		))
+		for k := start; k < end; {
+			if original[k] == fixed[k] {
+				sb.WriteString(" " + original[k] + "\n")
+				k++
+				continue
+			}
+			// Consecutive changed lines are removed first, then added
+			run := k
+			for run < end && original[run] != fixed[run] {
+				run++
+			}
+			for _, line := range original[k:run] {
+				sb.WriteString("-" + line + "\n")
+			}
+			for _, line := range fixed[k:run] {
+				sb.WriteString("+" + line + "\n")
+			}
+			k = run
+		}
+		i = j + 1
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fixer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+)
+
+var _ = Describe("Fixer", func() {
+	var (
+		ctx     context.Context
+		f       fixer.Fixer
+		tempDir string
+		note    string
+		result  *model.ValidationResult
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		f = fixer.New()
+
+		tempDir, err = os.MkdirTemp("", "fixer-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		note = filepath.Join(tempDir, "Note.md")
+		content := "# Note\n\nSee [[Projct Plan#Goals]].\nKeep [[Missing]].\n"
+		Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+		result = &model.ValidationResult{
+			BrokenLinks: map[string][]model.BrokenLink{
+				note: {
+					{
+						Link:      "[[Projct Plan#Goals]]",
+						Line:      3,
+						Offset:    12,
+						EndOffset: 33,
+						Fix:       "[[Project Plan#Goals]]",
+					},
+					{Link: "[[Missing]]", Line: 4, Offset: 40, EndOffset: 51},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	Context("Plan", func() {
+		It("replaces links with a fix", func() {
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].File).To(Equal(note))
+			Expect(changes[0].Fixed).
+				To(Equal("# Note\n\nSee [[Project Plan#Goals]].\nKeep [[Missing]].\n"))
+			Expect(changes[0].Edits).To(Equal([]fixer.Edit{
+				{Line: 3, Offset: 12, Old: "[[Projct Plan#Goals]]", New: "[[Project Plan#Goals]]"},
+			}))
+		})
+
+		It("does not write files", func() {
+			_, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			content, err := os.ReadFile(note)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring("[[Projct Plan#Goals]]"))
+		})
+
+		It("skips links no longer at their offset", func() {
+			Expect(os.WriteFile(note, []byte("# Changed\n\nSee [[Projct Plan#Goals]]."), 0600)).
+				To(Succeed())
+
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("keeps the escaped pipe of links in table cells", func() {
+			content := "| Link |\n| --- |\n| [[Projct\\|Plan]] |\n"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+			parsed, err := parser.New().ParseContent(ctx, note, content)
+			Expect(err).NotTo(HaveOccurred())
+			link := parsed.Links[0]
+			result.BrokenLinks[note] = []model.BrokenLink{{
+				Link:      link.Raw,
+				Line:      link.Line,
+				Offset:    link.Offset,
+				EndOffset: link.EndOffset,
+				Fix:       link.WithTarget("Project"),
+			}}
+
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Fixed).To(Equal("| Link |\n| --- |\n| [[Project\\|Plan]] |\n"))
+		})
+
+		It("skips files without fixes", func() {
+			result.BrokenLinks[note] = result.BrokenLinks[note][1:]
+
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	Context("Apply", func() {
+		It("writes fixed content", func() {
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Apply(ctx, changes)).To(Succeed())
+
+			content, err := os.ReadFile(note)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(changes[0].Fixed))
+		})
+	})
+
+	Context("Diff", func() {
+		It("returns unified diff", func() {
+			changes, err := f.Plan(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fixer.Diff(changes[0], "Note.md")).To(Equal(`--- a/Note.md
++++ b/Note.md
+@@ -1,4 +1,4 @@
+ # Note
+ 
+-See [[Projct Plan#Goals]].
++See [[Project Plan#Goals]].
+ Keep [[Missing]].
+`))
+		})
+	})
+})
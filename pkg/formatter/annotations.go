@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// NewGitHubFormatter creates a formatter emitting GitHub Actions workflow commands
+// with paths relative to the git repository containing the vault
+func NewGitHubFormatter() Formatter {
+	return &githubFormatter{}
+}
+
+type githubFormatter struct{}
+
+// Format outputs one ::error or ::warning command per finding
+func (f *githubFormatter) Format(
+	ctx context.Context,
+	result *model.ValidationResult,
+) (string, error) {
+	root := repoRoot(result.VaultPath)
+
+	var sb strings.Builder
+	for _, file := range sortedFiles(result) {
+		rel := relPath(root, absPath(file))
+		for _, link := range result.BrokenLinks[file] {
+			command := "error"
+			if link.Severity == model.SeverityWarning {
+				command = "warning"
+			}
+			properties := []string{"file=" + escapeProperty(rel)}
+			if link.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", link.Line))
+			}
+			if link.Column > 0 {
+				properties = append(
+					properties,
+					fmt.Sprintf("col=%d,endColumn=%d", link.Column, link.EndColumn),
+				)
+			}
+			properties = append(properties, "title="+escapeProperty(string(kindOf(link))))
+
+			sb.WriteString(fmt.Sprintf(
+				"::%s %s::%s\n",
+				command,
+				strings.Join(properties, ","),
+				escapeData(Message(link)),
+			))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// escapeData escapes the message of a workflow command
+func escapeData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeProperty escapes a property value of a workflow command
+func escapeProperty(value string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeData(value))
+}
+
+// NewGitLabFormatter creates a GitLab Code Quality report formatter with paths
+// relative to the git repository containing the vault
+func NewGitLabFormatter() Formatter {
+	return &gitlabFormatter{}
+}
+
+type gitlabFormatter struct{}
+
+type gitlabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitlabLocation `json:"location"`
+}
+
+type gitlabLocation struct {
+	Path  string      `json:"path"`
+	Lines gitlabLines `json:"lines"`
+}
+
+type gitlabLines struct {
+	Begin int `json:"begin"`
+}
+
+// Format outputs findings as Code Quality JSON array. Errors are major and
+// warnings minor issues, findings about a whole file point to line 1.
+func (f *gitlabFormatter) Format(
+	ctx context.Context,
+	result *model.ValidationResult,
+) (string, error) {
+	root := repoRoot(result.VaultPath)
+
+	issues := []gitlabIssue{}
+	for _, file := range sortedFiles(result) {
+		rel := relPath(root, absPath(file))
+		fingerprints := fingerprints(rel, result.BrokenLinks[file])
+		for i, link := range result.BrokenLinks[file] {
+			severity := "major"
+			if link.Severity == model.SeverityWarning {
+				severity = "minor"
+			}
+			issues = append(issues, gitlabIssue{
+				Description: Message(link),
+				CheckName:   string(kindOf(link)),
+				Fingerprint: fingerprints[i],
+				Severity:    severity,
+				Location: gitlabLocation{
+					Path:  rel,
+					Lines: gitlabLines{Begin: max(link.Line, 1)},
+				},
+			})
+		}
+	}
+
+	bytes, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "marshal code quality failed")
+	}
+
+	return string(bytes) + "\n", nil
+}
+
+// absPath returns the absolute form of path, or path if it cannot be determined
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// NewCheckstyleFormatter creates a Checkstyle XML formatter with file names relative to the vault
+func NewCheckstyleFormatter() Formatter {
+	return &checkstyleFormatter{}
+}
+
+type checkstyleFormatter struct{}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr,omitempty"` // omitted for findings about the whole file
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Format outputs one file element per file with findings
+func (f *checkstyleFormatter) Format(
+	ctx context.Context,
+	result *model.ValidationResult,
+) (string, error) {
+	report := checkstyleReport{Version: "4.3"}
+	for _, file := range sortedFiles(result) {
+		checkstyleFile := checkstyleFile{Name: relPath(result.VaultPath, file)}
+		for _, link := range result.BrokenLinks[file] {
+			severity := "error"
+			if link.Severity == model.SeverityWarning {
+				severity = "warning"
+			}
+			checkstyleFile.Errors = append(checkstyleFile.Errors, checkstyleError{
+				Line:     link.Line,
+				Column:   link.Column,
+				Severity: severity,
+				Message:  Message(link),
+				Source:   "obsidian-lint." + string(kindOf(link)),
+			})
+		}
+		report.Files = append(report.Files, checkstyleFile)
+	}
+
+	bytes, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "marshal checkstyle failed")
+	}
+
+	return xml.Header + string(bytes) + "\n", nil
+}
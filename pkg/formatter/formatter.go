@@ -8,7 +8,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 	"strings"
 
 	"github.com/bborbe/errors"
@@ -42,26 +41,80 @@ func (f *textFormatter) Format(
 	var sb strings.Builder
 	sb.WriteString("Broken links found in vault:\n\n")
 
-	// Sort files for consistent output
-	files := make([]string, 0, len(result.BrokenLinks))
-	for file := range result.BrokenLinks {
-		files = append(files, file)
+	// Sort files for consistent output, every finding starts with file:line:col
+	for _, file := range sortedFiles(result) {
+		for _, link := range result.BrokenLinks[file] {
+			sb.WriteString(location(file, link))
+			switch {
+			case link.Line == 0:
+				// File level findings like orphan notes have no link
+				sb.WriteString(fmt.Sprintf(": %s", link.Kind))
+				if link.Size > 0 {
+					sb.WriteString(fmt.Sprintf(" (%s)", formatSize(link.Size)))
+				}
+			case link.Detail != "":
+				// Frontmatter findings describe the problem instead of a link
+				sb.WriteString(fmt.Sprintf(": %s (%s)", Message(link), link.Kind))
+			case link.Kind != "" && link.Kind != model.KindBrokenLink:
+				sb.WriteString(fmt.Sprintf(": %s", linkText(link)))
+				sb.WriteString(fmt.Sprintf(" (%s%s)", link.Kind, formatCandidates(link)))
+			default:
+				sb.WriteString(fmt.Sprintf(": %s", linkText(link)))
+			}
+			if len(link.Suggestions) > 0 {
+				sb.WriteString(
+					fmt.Sprintf(" (did you mean: %s?)", strings.Join(link.Suggestions, ", ")),
+				)
+			}
+			if link.Severity == model.SeverityWarning {
+				sb.WriteString(" [warning]")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
 	}
-	sort.Strings(files)
 
-	for _, file := range files {
-		links := result.BrokenLinks[file]
+	return sb.String(), nil
+}
 
-		sb.WriteString(file)
-		sb.WriteString(":\n")
+// location returns file:line:col of a finding, without line and column if unknown
+func location(file string, link model.BrokenLink) string {
+	switch {
+	case link.Line == 0:
+		return file
+	case link.Column == 0:
+		return fmt.Sprintf("%s:%d", file, link.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", file, link.Line, link.Column)
+	}
+}
 
-		for _, link := range links {
-			sb.WriteString(fmt.Sprintf("  Line %d: %s\n", link.Line, link.Link))
-		}
-		sb.WriteString("\n")
+// formatCandidates lists the competing files of an ambiguous link
+func formatCandidates(link model.BrokenLink) string {
+	if len(link.Candidates) == 0 {
+		return ""
+	}
+	details := ": " + strings.Join(link.Candidates, ", ")
+	if link.Opens != "" {
+		details += "; opens " + link.Opens
 	}
+	return details
+}
 
-	return sb.String(), nil
+// formatSize formats a file size in bytes as B, KB, MB or GB
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB"} {
+		if value < unit {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f GB", value)
 }
 
 // NewJSONFormatter creates a JSON formatter
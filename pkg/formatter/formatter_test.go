@@ -7,6 +7,9 @@ package formatter_test
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -35,8 +38,8 @@ var _ = Describe("Formatter", func() {
 			result := &model.ValidationResult{
 				BrokenLinks: map[string][]model.BrokenLink{
 					"/vault/file1.md": {
-						{Link: "[[Dead1]]", Line: 5},
-						{Link: "[[Dead2]]", Line: 10},
+						{Link: "[[Dead1]]", Line: 5, Column: 3},
+						{Link: "[[Dead2]]", Line: 10, Column: 1},
 					},
 					"/vault/file2.md": {
 						{Link: "![[missing.png]]", Line: 3},
@@ -48,11 +51,168 @@ var _ = Describe("Formatter", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(output).To(ContainSubstring("Broken links found in vault:"))
-			Expect(output).To(ContainSubstring("/vault/file1.md:"))
-			Expect(output).To(ContainSubstring("Line 5: [[Dead1]]"))
-			Expect(output).To(ContainSubstring("Line 10: [[Dead2]]"))
-			Expect(output).To(ContainSubstring("/vault/file2.md:"))
-			Expect(output).To(ContainSubstring("Line 3: ![[missing.png]]"))
+			Expect(output).To(ContainSubstring("/vault/file1.md:5:3: [[Dead1]]\n"))
+			Expect(output).To(ContainSubstring("/vault/file1.md:10:1: [[Dead2]]\n"))
+			Expect(output).To(ContainSubstring("/vault/file2.md:3: ![[missing.png]]\n"))
+		})
+
+		It("names unknown kinds of unused suppressions", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{
+							Link:   "%% obsidian-lint-disable broken-links %%",
+							Line:   1,
+							Kind:   model.KindUnusedSuppression,
+							Detail: "unknown kind broken-links",
+						},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(
+				"/vault/file1.md:1: Unused suppression %% obsidian-lint-disable broken-links %%: " +
+					"unknown kind broken-links (unused-suppression)\n",
+			))
+		})
+
+		It("shows kind for findings other than broken links", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{Link: "[[Dead]]", Line: 1, Kind: model.KindBrokenLink},
+						{Link: "[[Note#Gone]]", Line: 2, Kind: model.KindBrokenHeading},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring("/vault/file1.md:1: [[Dead]]\n"))
+			Expect(output).To(ContainSubstring("/vault/file1.md:2: [[Note#Gone]] (broken-heading)\n"))
+		})
+
+		It("lists candidates of ambiguous links", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {{
+						Link:       "[[Meeting]]",
+						Line:       3,
+						Kind:       model.KindAmbiguousLink,
+						Candidates: []string{"A/Meeting.md", "B/Meeting.md"},
+						Opens:      "A/Meeting.md",
+					}},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(
+				":3: [[Meeting]] (ambiguous-link: A/Meeting.md, B/Meeting.md; opens A/Meeting.md)\n",
+			))
+		})
+
+		It("marks warnings", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{Link: "[[Dead]]", Line: 1, Kind: model.KindBrokenLink, Severity: model.SeverityWarning},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(":1: [[Dead]] [warning]\n"))
+		})
+
+		It("shows file level findings without line", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{Kind: model.KindOrphanNote, Severity: model.SeverityWarning},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring("/vault/file1.md: orphan-note [warning]\n"))
+		})
+
+		It("shows size of unused attachments", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/image.png": {
+						{Kind: model.KindUnusedAttachment, Size: 1536},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring("/vault/image.png: unused-attachment (1.5 KB)\n"))
+		})
+
+		It("names the property of links in frontmatter", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{Link: "[[Gone]]", Line: 2, Column: 11, Property: "related"},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).
+				To(ContainSubstring("/vault/file1.md:2:11: [[Gone]] in property related\n"))
+		})
+
+		It("describes frontmatter findings", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{
+							Link:     "waiting",
+							Line:     3,
+							Column:   9,
+							Kind:     model.KindSchemaViolation,
+							Property: "status",
+							Detail:   "must be one of active, done",
+						},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(
+				"/vault/file1.md:3:9: Property status must be one of active, done" +
+					" (schema-violation)\n",
+			))
+		})
+
+		It("shows suggestions", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{
+							Link:        "[[Projct]]",
+							Line:        2,
+							Kind:        model.KindBrokenLink,
+							Suggestions: []string{"Project", "Projects"},
+						},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).
+				To(ContainSubstring(":2: [[Projct]] (did you mean: Project, Projects?)\n"))
 		})
 
 		It("returns success message when no broken links", func() {
@@ -97,8 +257,8 @@ var _ = Describe("Formatter", func() {
 			result := &model.ValidationResult{
 				BrokenLinks: map[string][]model.BrokenLink{
 					"/vault/file1.md": {
-						{Link: "[[Dead1]]", Line: 5},
-						{Link: "[[Dead2]]", Line: 10},
+						{Link: "[[Dead1]]", Line: 5, Column: 3},
+						{Link: "[[Dead2]]", Line: 10, Column: 1},
 					},
 					"/vault/file2.md": {
 						{Link: "![[missing.png]]", Line: 3},
@@ -125,6 +285,21 @@ var _ = Describe("Formatter", func() {
 			Expect(parsed["/vault/file2.md"][0].Line).To(Equal(3))
 		})
 
+		It("includes suggestions", func() {
+			result := &model.ValidationResult{
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/file1.md": {
+						{Link: "[[Projct]]", Line: 2, Suggestions: []string{"Project"}},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(`"suggestions": [`))
+			Expect(output).To(ContainSubstring(`"Project"`))
+		})
+
 		It("returns empty JSON object when no broken links", func() {
 			result := &model.ValidationResult{
 				BrokenLinks: map[string][]model.BrokenLink{},
@@ -155,8 +330,346 @@ var _ = Describe("Formatter", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Context("SARIFFormatter", func() {
+		var (
+			f      formatter.Formatter
+			result *model.ValidationResult
+		)
+
+		BeforeEach(func() {
+			f = formatter.NewSARIFFormatter()
+			result = &model.ValidationResult{
+				VaultPath: "/vault",
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/Daily Notes/file1.md": {
+						{
+							Link:           "[[Dead]]",
+							Line:           5,
+							Column:         3,
+							EndColumn:      11,
+							ColumnUTF16:    4,
+							EndColumnUTF16: 12,
+							Kind:           model.KindBrokenLink,
+						},
+						{Link: "[[Dead]]", Line: 9, Kind: model.KindBrokenLink},
+					},
+					"/vault/image.png": {
+						{Kind: model.KindUnusedAttachment, Severity: model.SeverityWarning, Size: 10},
+					},
+				},
+			}
+		})
+
+		It("outputs SARIF 2.1.0 with rules and results", func() {
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var log sarifLog
+			Expect(json.Unmarshal([]byte(output), &log)).To(Succeed())
+			Expect(log.Version).To(Equal("2.1.0"))
+			Expect(log.Runs).To(HaveLen(1))
+			run := log.Runs[0]
+			Expect(run.Tool.Driver.Name).To(Equal("obsidian-lint"))
+			Expect(run.Results).To(HaveLen(3))
+
+			first := run.Results[0]
+			Expect(first.RuleID).To(Equal("broken-link"))
+			Expect(run.Tool.Driver.Rules[first.RuleIndex].ID).To(Equal("broken-link"))
+			Expect(first.Level).To(Equal("error"))
+			Expect(first.Message.Text).To(Equal("Broken link [[Dead]]"))
+			location := first.Locations[0].PhysicalLocation
+			Expect(location.ArtifactLocation.URI).To(Equal("Daily%20Notes/file1.md"))
+			Expect(location.Region.StartLine).To(Equal(5))
+			Expect(location.Region.StartColumn).To(Equal(4))
+			Expect(location.Region.EndColumn).To(Equal(12))
+
+			attachment := run.Results[2]
+			Expect(attachment.RuleID).To(Equal("unused-attachment"))
+			Expect(attachment.Level).To(Equal("warning"))
+			Expect(attachment.Locations[0].PhysicalLocation.Region).To(BeNil())
+
+			Expect(first.PartialFingerprints["obsidianLint/v1"]).
+				NotTo(Equal(run.Results[1].PartialFingerprints["obsidianLint/v1"]))
+		})
+
+		It("keeps fingerprints stable when lines move", func() {
+			before, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			result.BrokenLinks["/vault/Daily Notes/file1.md"][0].Line = 6
+			result.BrokenLinks["/vault/Daily Notes/file1.md"][1].Line = 10
+			after, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var beforeLog, afterLog sarifLog
+			Expect(json.Unmarshal([]byte(before), &beforeLog)).To(Succeed())
+			Expect(json.Unmarshal([]byte(after), &afterLog)).To(Succeed())
+			for i := range beforeLog.Runs[0].Results {
+				Expect(afterLog.Runs[0].Results[i].PartialFingerprints).
+					To(Equal(beforeLog.Runs[0].Results[i].PartialFingerprints))
+			}
+		})
+
+		It("derives the default level of rules from their severity", func() {
+			result.Severities = map[model.Kind]model.Severity{
+				model.KindBrokenLink:       model.SeverityError,
+				model.KindAmbiguousLink:    model.SeverityWarning,
+				model.KindUnusedAttachment: model.SeverityOff,
+			}
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var log sarifLog
+			Expect(json.Unmarshal([]byte(output), &log)).To(Succeed())
+			levels := make(map[string]string)
+			for _, rule := range log.Runs[0].Tool.Driver.Rules {
+				levels[rule.ID] = rule.DefaultConfiguration.Level
+			}
+			Expect(levels["broken-link"]).To(Equal("error"))
+			Expect(levels["ambiguous-link"]).To(Equal("warning"))
+			Expect(levels["unused-attachment"]).To(Equal("none"))
+		})
+
+		It("outputs empty results array when no findings", func() {
+			output, err := f.Format(ctx, &model.ValidationResult{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(ContainSubstring(`"results": []`))
+		})
+	})
+
+	Context("JUnitFormatter", func() {
+		var f formatter.Formatter
+
+		BeforeEach(func() {
+			f = formatter.NewJUnitFormatter()
+		})
+
+		It("outputs one test case per note with failures for errors", func() {
+			result := &model.ValidationResult{
+				VaultPath: "/vault",
+				Files:     []string{"/vault/Good.md", "/vault/Bad.md"},
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/Bad.md": {
+						{Link: "[[Dead]]", Line: 5, Severity: model.SeverityError},
+						{Link: "[[Dead2]]", Line: 6, Kind: model.KindBrokenLink},
+						{
+							Link:     "[[Meeting]]",
+							Line:     7,
+							Kind:     model.KindAmbiguousLink,
+							Severity: model.SeverityWarning,
+						},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var suites struct {
+				Tests    int `xml:"tests,attr"`
+				Failures int `xml:"failures,attr"`
+				Suites   []struct {
+					TestCases []struct {
+						Name     string `xml:"name,attr"`
+						Failures []struct {
+							Message string `xml:"message,attr"`
+							Type    string `xml:"type,attr"`
+						} `xml:"failure"`
+						SystemOut string `xml:"system-out"`
+					} `xml:"testcase"`
+				} `xml:"testsuite"`
+			}
+			Expect(xml.Unmarshal([]byte(output), &suites)).To(Succeed())
+			Expect(suites.Tests).To(Equal(2))
+			Expect(suites.Failures).To(Equal(1))
+			testCases := suites.Suites[0].TestCases
+			Expect(testCases).To(HaveLen(2))
+			Expect(testCases[0].Name).To(Equal("Bad.md"))
+			Expect(testCases[0].Failures).To(HaveLen(2))
+			Expect(testCases[0].Failures[0].Message).To(Equal("Broken link [[Dead]]"))
+			Expect(testCases[0].Failures[0].Type).To(Equal("broken-link"))
+			Expect(testCases[0].SystemOut).
+				To(Equal("warning: Bad.md:7: Ambiguous link [[Meeting]]"))
+			Expect(testCases[1].Name).To(Equal("Good.md"))
+			Expect(testCases[1].Failures).To(BeEmpty())
+		})
+	})
+
+	Context("CheckstyleFormatter", func() {
+		var f formatter.Formatter
+
+		BeforeEach(func() {
+			f = formatter.NewCheckstyleFormatter()
+		})
+
+		It("outputs errors per file", func() {
+			result := &model.ValidationResult{
+				VaultPath: "/vault",
+				BrokenLinks: map[string][]model.BrokenLink{
+					"/vault/Bad.md": {
+						{Link: "[[Dead]]", Line: 5, Column: 7, Kind: model.KindBrokenLink},
+						{Kind: model.KindOrphanNote, Severity: model.SeverityWarning},
+					},
+				},
+			}
+
+			output, err := f.Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(HavePrefix(xml.Header))
+			Expect(output).To(ContainSubstring(`<checkstyle version="4.3">`))
+			Expect(output).To(ContainSubstring(`<file name="Bad.md">`))
+			Expect(output).To(ContainSubstring(
+				`<error line="5" column="7" severity="error" message="Broken link [[Dead]]" ` +
+					`source="obsidian-lint.broken-link"></error>`,
+			))
+			Expect(output).To(ContainSubstring(
+				`<error severity="warning" message="Note has no incoming links" ` +
+					`source="obsidian-lint.orphan-note"></error>`,
+			))
+		})
+	})
+
+	Context("annotation formatters", func() {
+		var (
+			tempDir string
+			result  *model.ValidationResult
+			err     error
+		)
+
+		BeforeEach(func() {
+			tempDir, err = os.MkdirTemp("", "formatter-test")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".git"), 0750)).To(Succeed())
+			vault := filepath.Join(tempDir, "docs", "vault")
+			Expect(os.MkdirAll(vault, 0750)).To(Succeed())
+
+			result = &model.ValidationResult{
+				VaultPath: vault,
+				BrokenLinks: map[string][]model.BrokenLink{
+					filepath.Join(vault, "Note.md"): {
+						{Link: "[[Dead]]", Line: 5, Column: 2, EndColumn: 10, Kind: model.KindBrokenLink},
+						{
+							Link:       "[[Meeting]]",
+							Line:       7,
+							Kind:       model.KindAmbiguousLink,
+							Severity:   model.SeverityWarning,
+							Candidates: []string{"A/Meeting.md", "B/Meeting.md"},
+						},
+					},
+					filepath.Join(vault, "image.png"): {
+						{Kind: model.KindUnusedAttachment, Severity: model.SeverityWarning, Size: 10},
+					},
+				},
+			}
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tempDir)
+		})
+
+		It("outputs GitHub workflow commands with repository paths", func() {
+			output, err := formatter.NewGitHubFormatter().Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal(
+				"::error file=docs/vault/Note.md,line=5,col=2,endColumn=10,title=broken-link::" +
+					"Broken link [[Dead]]\n" +
+					"::warning file=docs/vault/Note.md,line=7,title=ambiguous-link::" +
+					"Ambiguous link [[Meeting]]: A/Meeting.md, B/Meeting.md\n" +
+					"::warning file=docs/vault/image.png,title=unused-attachment::" +
+					"Unused attachment (10 B)\n",
+			))
+		})
+
+		It("outputs GitLab Code Quality JSON with repository paths", func() {
+			output, err := formatter.NewGitLabFormatter().Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var issues []struct {
+				Description string `json:"description"`
+				CheckName   string `json:"check_name"`
+				Fingerprint string `json:"fingerprint"`
+				Severity    string `json:"severity"`
+				Location    struct {
+					Path  string `json:"path"`
+					Lines struct {
+						Begin int `json:"begin"`
+					} `json:"lines"`
+				} `json:"location"`
+			}
+			Expect(json.Unmarshal([]byte(output), &issues)).To(Succeed())
+			Expect(issues).To(HaveLen(3))
+			Expect(issues[0].CheckName).To(Equal("broken-link"))
+			Expect(issues[0].Severity).To(Equal("major"))
+			Expect(issues[0].Location.Path).To(Equal("docs/vault/Note.md"))
+			Expect(issues[0].Location.Lines.Begin).To(Equal(5))
+			Expect(issues[0].Fingerprint).NotTo(BeEmpty())
+			Expect(issues[1].Severity).To(Equal("minor"))
+			Expect(issues[2].Location.Path).To(Equal("docs/vault/image.png"))
+			Expect(issues[2].Location.Lines.Begin).To(Equal(1))
+		})
+
+		It("outputs SARIF with repository paths", func() {
+			output, err := formatter.NewSARIFFormatter().Format(ctx, result)
+			Expect(err).NotTo(HaveOccurred())
+
+			var log sarifLog
+			Expect(json.Unmarshal([]byte(output), &log)).To(Succeed())
+			results := log.Runs[0].Results
+			Expect(results).To(HaveLen(3))
+			Expect(results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI).
+				To(Equal("docs/vault/Note.md"))
+			Expect(results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI).
+				To(Equal("docs/vault/image.png"))
+		})
+
+		It("outputs empty GitLab report when no findings", func() {
+			output, err := formatter.NewGitLabFormatter().Format(ctx, &model.ValidationResult{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal("[]\n"))
+		})
+	})
 })
 
+// sarifLog contains the parts of a SARIF log checked by the tests
+type sarifLog struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					ID                   string `json:"id"`
+					DefaultConfiguration struct {
+						Level string `json:"level"`
+					} `json:"defaultConfiguration"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleID    string `json:"ruleId"`
+			RuleIndex int    `json:"ruleIndex"`
+			Level     string `json:"level"`
+			Message   struct {
+				Text string `json:"text"`
+			} `json:"message"`
+			Locations []struct {
+				PhysicalLocation struct {
+					ArtifactLocation struct {
+						URI string `json:"uri"`
+					} `json:"artifactLocation"`
+					Region *struct {
+						StartLine   int `json:"startLine"`
+						StartColumn int `json:"startColumn"`
+						EndColumn   int `json:"endColumn"`
+					} `json:"region"`
+				} `json:"physicalLocation"`
+			} `json:"locations"`
+			PartialFingerprints map[string]string `json:"partialFingerprints"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
 // indexOf returns the index of substr in s, or -1 if not found
 func indexOf(s, substr string) int {
 	for i := 0; i <= len(s)-len(substr); i++ {
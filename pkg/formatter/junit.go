@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// NewJUnitFormatter creates a JUnit XML formatter with test names relative to the vault
+func NewJUnitFormatter() Formatter {
+	return &junitFormatter{}
+}
+
+type junitFormatter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+	SystemOut string         `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Format outputs one test case per note and per file with findings. Every error
+// is a failure of its test case, warnings are written to system-out.
+func (f *junitFormatter) Format(
+	ctx context.Context,
+	result *model.ValidationResult,
+) (string, error) {
+	suite := junitTestSuite{Name: "obsidian-lint"}
+	for _, file := range resultFiles(result) {
+		testCase := junitTestCase{
+			Name:      relPath(result.VaultPath, file),
+			ClassName: "obsidian-lint",
+		}
+		var warnings []string
+		for _, link := range result.BrokenLinks[file] {
+			text := fmt.Sprintf("%s: %s", location(testCase.Name, link), Message(link))
+			if link.Severity == model.SeverityWarning {
+				warnings = append(warnings, "warning: "+text)
+				continue
+			}
+			testCase.Failures = append(testCase.Failures, junitFailure{
+				Message: Message(link),
+				Type:    string(kindOf(link)),
+				Text:    text,
+			})
+		}
+		testCase.SystemOut = strings.Join(warnings, "\n")
+
+		suite.Tests++
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	bytes, err := xml.MarshalIndent(junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "marshal junit failed")
+	}
+
+	return xml.Header + string(bytes) + "\n", nil
+}
+
+// resultFiles returns all validated notes and all files with findings, sorted
+func resultFiles(result *model.ValidationResult) []string {
+	seen := make(map[string]struct{}, len(result.Files)+len(result.BrokenLinks))
+	var files []string
+	for _, file := range result.Files {
+		if _, exists := seen[file]; !exists {
+			seen[file] = struct{}{}
+			files = append(files, file)
+		}
+	}
+	for file := range result.BrokenLinks {
+		if _, exists := seen[file]; !exists {
+			seen[file] = struct{}{}
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
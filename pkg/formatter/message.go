@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// rule describes a finding kind for formats with rule metadata
+type rule struct {
+	Kind        model.Kind
+	Description string
+}
+
+// rules lists all finding kinds in a stable order
+var rules = []rule{
+	{model.KindBrokenLink, "Link target note or file does not exist"},
+	{model.KindBrokenHeading, "Linked heading does not exist in the target note"},
+	{model.KindBrokenBlock, "Linked block ID does not exist in the target note"},
+	{model.KindAmbiguousLink, "Link target matches several files"},
+	{model.KindUnusedSuppression, "Suppression marker suppresses no finding"},
+	{model.KindOrphanNote, "Note has no incoming links or embeds"},
+	{model.KindUnusedAttachment, "Attachment is not linked or embedded by any note or canvas"},
+	{model.KindMalformedFrontmatter, "Frontmatter is not valid YAML"},
+	{model.KindSchemaViolation, "Frontmatter property does not match the schema"},
+}
+
+// kindOf returns the kind of a finding, findings without kind are broken links
+func kindOf(link model.BrokenLink) model.Kind {
+	if link.Kind == "" {
+		return model.KindBrokenLink
+	}
+	return link.Kind
+}
+
+// Message returns a one line description of a finding
+func Message(link model.BrokenLink) string {
+	var msg string
+	switch kindOf(link) {
+	case model.KindBrokenLink:
+		msg = "Broken link " + linkText(link)
+	case model.KindBrokenHeading:
+		msg = "Heading not found: " + linkText(link)
+	case model.KindBrokenBlock:
+		msg = "Block not found: " + linkText(link)
+	case model.KindAmbiguousLink:
+		msg = "Ambiguous link " + linkText(link) + formatCandidates(link)
+	case model.KindUnusedSuppression:
+		msg = "Unused suppression " + link.Link
+		if link.Detail != "" {
+			msg += ": " + link.Detail
+		}
+	case model.KindOrphanNote:
+		msg = "Note has no incoming links"
+	case model.KindUnusedAttachment:
+		msg = fmt.Sprintf("Unused attachment (%s)", formatSize(link.Size))
+	case model.KindMalformedFrontmatter:
+		msg = "Malformed frontmatter: " + link.Detail
+	case model.KindSchemaViolation:
+		msg = fmt.Sprintf("Property %s %s", link.Property, link.Detail)
+	default:
+		msg = fmt.Sprintf("%s %s", link.Kind, link.Link)
+	}
+	if len(link.Suggestions) > 0 {
+		msg += fmt.Sprintf(" (did you mean: %s?)", strings.Join(link.Suggestions, ", "))
+	}
+	return msg
+}
+
+// linkText returns the link of a finding and the frontmatter property containing it
+func linkText(link model.BrokenLink) string {
+	if link.Property == "" {
+		return link.Link
+	}
+	return link.Link + " in property " + link.Property
+}
+
+// sortedFiles returns the files of a result in alphabetical order
+func sortedFiles(result *model.ValidationResult) []string {
+	files := make([]string, 0, len(result.BrokenLinks))
+	for file := range result.BrokenLinks {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// relPath returns file relative to base with forward slashes, or file if it is not below base
+func relPath(base string, file string) string {
+	rel, err := filepath.Rel(base, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// fingerprints returns a stable fingerprint for each finding of a file. They hash the
+// kind, file, link and its occurrence in the file, so they survive added or removed lines.
+func fingerprints(rel string, links []model.BrokenLink) []string {
+	result := make([]string, 0, len(links))
+	occurrences := make(map[string]int)
+	for _, link := range links {
+		key := fmt.Sprintf("%s\x00%s\x00%s", kindOf(link), rel, link.Link)
+		occurrences[key]++
+		hash := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrences[key])))
+		result = append(result, hex.EncodeToString(hash[:]))
+	}
+	return result
+}
+
+// repoRoot returns the closest directory containing .git at or above path,
+// or path itself if it is not inside a git repository
+func repoRoot(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	for dir := abs; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return abs
+		}
+	}
+}
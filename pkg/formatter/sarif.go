@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formatter
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// NewSARIFFormatter creates a SARIF 2.1.0 formatter with artifact URIs relative to the
+// git repository containing the vault
+func NewSARIFFormatter() Formatter {
+	return &sarifFormatter{}
+}
+
+type sarifFormatter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"` // UTF-16 code units, the SARIF default
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// Format outputs findings as SARIF log with one run
+func (f *sarifFormatter) Format(
+	ctx context.Context,
+	result *model.ValidationResult,
+) (string, error) {
+	driver := sarifDriver{
+		Name:           "obsidian-lint",
+		InformationURI: "https://github.com/bborbe/obsidian-lint",
+	}
+	ruleIndex := make(map[model.Kind]int, len(rules))
+	for i, r := range rules {
+		ruleIndex[r.Kind] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   string(r.Kind),
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(result.Severities[r.Kind])},
+		})
+	}
+
+	root := repoRoot(result.VaultPath)
+	results := []sarifResult{}
+	for _, file := range sortedFiles(result) {
+		rel := relPath(root, absPath(file))
+		fingerprints := fingerprints(rel, result.BrokenLinks[file])
+		for i, link := range result.BrokenLinks[file] {
+			kind := kindOf(link)
+			location := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{
+					URI:       (&url.URL{Path: rel}).String(),
+					URIBaseID: "%SRCROOT%",
+				},
+			}
+			if link.Line > 0 {
+				location.Region = &sarifRegion{
+					StartLine:   link.Line,
+					StartColumn: link.ColumnUTF16,
+					EndColumn:   link.EndColumnUTF16,
+				}
+			}
+
+			results = append(results, sarifResult{
+				RuleID:    string(kind),
+				RuleIndex: ruleIndex[kind],
+				Level:     sarifLevel(link.Severity),
+				Message:   sarifMessage{Text: Message(link)},
+				Locations: []sarifLocation{{PhysicalLocation: location}},
+				PartialFingerprints: map[string]string{
+					"obsidianLint/v1": fingerprints[i],
+				},
+			})
+		}
+	}
+
+	bytes, err := json.MarshalIndent(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(ctx, err, "marshal sarif failed")
+	}
+
+	return string(bytes) + "\n", nil
+}
+
+// sarifLevel maps a severity to a SARIF level, checks without severity are errors
+func sarifLevel(severity model.Severity) string {
+	switch severity {
+	case model.SeverityWarning:
+		return "warning"
+	case model.SeverityOff:
+		return "none"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o ../../mocks/git.go --fake-name Git . Git
+
+// Git reads changed files from the local git repository containing a vault
+type Git interface {
+	// ChangedSince returns the files changed between ref and the working tree,
+	// including uncommitted changes and untracked files
+	ChangedSince(ctx context.Context, dir string, ref string) (*Diff, error)
+	// Staged returns the files changed in the git index and the staged content of
+	// changed notes
+	Staged(ctx context.Context, dir string) (*Diff, error)
+}
+
+// Diff contains the absolute paths of changed files inside the directory passed to Git
+type Diff struct {
+	Changed  []string          // added, modified and renamed files
+	Removed  []string          // deleted files and old paths of renamed files
+	Contents map[string]string // path -> staged content of changed notes, nil for working tree
+}
+
+// New creates a new Git using the git binary in PATH
+func New() Git {
+	return &gitCommand{}
+}
+
+type gitCommand struct{}
+
+// diffArgs lists changed files below the directory with paths relative to it
+var diffArgs = []string{"diff", "--name-status", "-z", "--relative", "--find-renames"}
+
+// ChangedSince runs git diff against ref and lists untracked files
+func (g *gitCommand) ChangedSince(ctx context.Context, dir string, ref string) (*Diff, error) {
+	output, err := g.run(ctx, dir, append(diffArgs, ref, "--")...)
+	if err != nil {
+		return nil, err
+	}
+	diff := parseNameStatus(dir, output)
+
+	untracked, err := g.run(ctx, dir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range strings.Split(untracked, "\x00") {
+		if path != "" {
+			diff.Changed = append(diff.Changed, filepath.Join(dir, filepath.FromSlash(path)))
+		}
+	}
+
+	return diff, nil
+}
+
+// Staged runs git diff --cached and reads the staged blob of every changed note,
+// so a pre-commit hook lints what is committed instead of unstaged edits
+func (g *gitCommand) Staged(ctx context.Context, dir string) (*Diff, error) {
+	output, err := g.run(ctx, dir, append(diffArgs, "--cached")...)
+	if err != nil {
+		return nil, err
+	}
+	diff := parseNameStatus(dir, output)
+
+	diff.Contents = make(map[string]string)
+	for _, path := range diff.Changed {
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			continue
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "relative path of %s failed", path)
+		}
+		content, err := g.run(ctx, dir, "show", ":./"+filepath.ToSlash(rel))
+		if err != nil {
+			return nil, err
+		}
+		diff.Contents[path] = content
+	}
+
+	return diff, nil
+}
+
+// run executes git in dir and returns its standard output
+func (g *gitCommand) run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec G204 -- arguments are fixed git subcommands and a user given ref
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(
+			ctx,
+			err,
+			"git %s failed: %s",
+			args[0],
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+	return stdout.String(), nil
+}
+
+// parseNameStatus parses the output of git diff --name-status -z. Each entry is
+// a status followed by one path, or two paths for renames and copies.
+func parseNameStatus(dir string, output string) *Diff {
+	diff := &Diff{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" || i+1 >= len(fields) {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(fields[i+1]))
+		i++
+
+		switch status[0] {
+		case 'D':
+			diff.Removed = append(diff.Removed, path)
+		case 'R', 'C':
+			if i+1 >= len(fields) {
+				continue
+			}
+			if status[0] == 'R' {
+				diff.Removed = append(diff.Removed, path)
+			}
+			diff.Changed = append(
+				diff.Changed,
+				filepath.Join(dir, filepath.FromSlash(fields[i+1])),
+			)
+			i++
+		default:
+			diff.Changed = append(diff.Changed, path)
+		}
+	}
+	return diff
+}
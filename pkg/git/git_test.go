@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package git_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/git"
+)
+
+var _ = Describe("Git", func() {
+	var (
+		ctx     context.Context
+		g       git.Git
+		repo    string
+		vault   string
+		err     error
+		run     func(args ...string)
+		write   func(name string, content string)
+		initial func()
+	)
+
+	BeforeEach(func() {
+		if _, err := exec.LookPath("git"); err != nil {
+			Skip("git not installed")
+		}
+		ctx = context.Background()
+		g = git.New()
+
+		repo, err = os.MkdirTemp("", "git-test")
+		Expect(err).NotTo(HaveOccurred())
+		vault = filepath.Join(repo, "vault")
+		Expect(os.MkdirAll(vault, 0750)).To(Succeed())
+
+		run = func(args ...string) {
+			// #nosec G204 -- test helper with fixed arguments
+			cmd := exec.Command("git", append([]string{
+				"-C", repo,
+				"-c", "user.name=test",
+				"-c", "user.email=test@example.com",
+				"-c", "commit.gpgsign=false",
+			}, args...)...)
+			output, err := cmd.CombinedOutput()
+			Expect(err).NotTo(HaveOccurred(), string(output))
+		}
+		write = func(name string, content string) {
+			path := filepath.Join(repo, name)
+			Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		}
+		initial = func() {
+			run("init", "-q")
+			write("vault/Modified.md", "old")
+			write("vault/Deleted.md", "deleted")
+			write("vault/Renamed.md", "a note that is renamed without changes")
+			write("Outside.md", "outside")
+			run("add", "-A")
+			run("commit", "-q", "-m", "initial")
+		}
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(repo)
+	})
+
+	Context("ChangedSince", func() {
+		It("returns changed, untracked and removed files below the directory", func() {
+			initial()
+			write("vault/Modified.md", "new")
+			write("vault/Untracked.md", "untracked")
+			write("Outside.md", "changed outside the vault")
+			run("rm", "-q", "vault/Deleted.md")
+			run("mv", "vault/Renamed.md", "vault/New Name.md")
+
+			diff, err := g.ChangedSince(ctx, vault, "HEAD")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Changed).To(ConsistOf(
+				filepath.Join(vault, "Modified.md"),
+				filepath.Join(vault, "New Name.md"),
+				filepath.Join(vault, "Untracked.md"),
+			))
+			Expect(diff.Removed).To(ConsistOf(
+				filepath.Join(vault, "Deleted.md"),
+				filepath.Join(vault, "Renamed.md"),
+			))
+		})
+
+		It("returns error for unknown ref", func() {
+			initial()
+
+			_, err := g.ChangedSince(ctx, vault, "does-not-exist")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Staged", func() {
+		It("returns only staged files", func() {
+			initial()
+			write("vault/Modified.md", "new")
+			write("vault/Added.md", "added")
+			run("add", "vault/Added.md")
+
+			diff, err := g.Staged(ctx, vault)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Changed).To(ConsistOf(filepath.Join(vault, "Added.md")))
+			Expect(diff.Removed).To(BeEmpty())
+		})
+
+		It("returns the staged content of changed notes", func() {
+			initial()
+			write("vault/Modified.md", "staged")
+			run("add", "vault/Modified.md")
+			write("vault/Modified.md", "unstaged")
+
+			diff, err := g.Staged(ctx, vault)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Contents).To(Equal(map[string]string{
+				filepath.Join(vault, "Modified.md"): "staged",
+			}))
+		})
+
+		It("returns error outside a git repository", func() {
+			_, err := g.Staged(ctx, vault)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
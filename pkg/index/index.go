@@ -8,11 +8,16 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/bborbe/errors"
 
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/pool"
 )
 
 //counterfeiter:generate -o ../../mocks/index_builder.go --fake-name IndexBuilder . Builder
@@ -22,26 +27,37 @@ type Builder interface {
 	Build(ctx context.Context, vaultPath string, files []string) (*VaultIndex, error)
 }
 
-// New creates a new Builder
-func New(parser parser.Parser) Builder {
+// New creates a new Builder that skips vault paths excluded by matcher and parses
+// notes on up to workers goroutines (0 for one per CPU)
+func New(parser parser.Parser, matcher exclude.Matcher, workers int) Builder {
 	return &indexBuilder{
-		parser: parser,
+		parser:  parser,
+		matcher: matcher,
+		workers: workers,
 	}
 }
 
 type indexBuilder struct {
-	parser parser.Parser
+	parser  parser.Parser
+	matcher exclude.Matcher
+	workers int
 }
 
-// Build creates a VaultIndex from markdown files and all files in vault
+// Build creates a VaultIndex from markdown files and all files in vault that are not excluded
 func (b *indexBuilder) Build(
 	ctx context.Context,
 	vaultPath string,
 	files []string,
 ) (*VaultIndex, error) {
 	index := &VaultIndex{
-		files:   make(map[string]string),
-		aliases: make(map[string]string),
+		vaultPath:  vaultPath,
+		paths:      make(map[string]string),
+		files:      make(map[string][]string),
+		aliases:    make(map[string]string),
+		aliasNames: make(map[string]string),
+		headings:   make(map[string]map[string]struct{}),
+		blocks:     make(map[string]map[string]struct{}),
+		notePaths:  make(map[string]*model.ParsedFile),
 	}
 
 	// Index all files in vault (for embeds to images, PDFs, etc.)
@@ -49,15 +65,22 @@ func (b *indexBuilder) Build(
 		if err != nil {
 			return err
 		}
+
+		relPath, err := filepath.Rel(vaultPath, path)
+		if err != nil {
+			return err
+		}
+		if relPath != "." && b.matcher.Match(filepath.ToSlash(relPath)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if d.IsDir() {
 			return nil
 		}
 
-		// Index all files by normalized basename
-		baseName := filepath.Base(path)
-		normalized := normalizeTarget(baseName)
-		index.files[normalized] = path
-
+		index.addFile(path, relPath)
 		return nil
 	})
 
@@ -65,49 +88,332 @@ func (b *indexBuilder) Build(
 		return nil, errors.Wrap(ctx, err, "walk vault failed")
 	}
 
-	// Parse and index aliases from markdown files
-	for _, file := range files {
-		// #nosec G304 -- file paths come from scanner.Scan(), not user input
-		content, err := os.ReadFile(file)
+	// Parse every markdown file once, the validator reuses the parsed links
+	index.notes = make([]*model.ParsedFile, len(files))
+	err = pool.Run(ctx, b.workers, len(files), func(ctx context.Context, i int) error {
+		parsed, err := b.parser.Parse(ctx, files[i])
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, "read file failed")
-		}
-
-		aliases, err := b.parser.ParseAliases(ctx, string(content))
-		if err != nil {
-			return nil, errors.Wrap(ctx, err, "parse aliases failed")
+			return errors.Wrapf(ctx, err, "parse %s failed", files[i])
 		}
+		index.notes[i] = parsed
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "parse files failed")
+	}
 
-		for _, alias := range aliases {
-			normalizedAlias := normalizeTarget(alias)
-			index.aliases[normalizedAlias] = file
-		}
+	for _, note := range index.notes {
+		index.addNote(note)
 	}
+	index.indexAliases()
 
 	return index, nil
 }
 
 // VaultIndex contains normalized file and alias mappings
 type VaultIndex struct {
-	files   map[string]string // normalized filename -> absolute path
-	aliases map[string]string // normalized alias -> absolute path
+	vaultPath  string
+	paths      map[string]string              // normalized vault-relative path -> absolute path
+	files      map[string][]string            // normalized path suffix -> absolute paths
+	aliases    map[string]string              // normalized alias -> absolute path
+	aliasNames map[string]string              // normalized alias -> alias as written
+	headings   map[string]map[string]struct{} // absolute path -> normalized headings
+	blocks     map[string]map[string]struct{} // absolute path -> lowercase block IDs
+	notes      []*model.ParsedFile            // parsed markdown files in scan order
+	notePaths  map[string]*model.ParsedFile   // absolute path -> parsed markdown file
+	names      []Name                         // sorted names, nil until first needed
+}
+
+// Add adds or replaces the file at path. note is the parsed content of markdown
+// notes and nil for all other files.
+func (v *VaultIndex) Add(path string, note *model.ParsedFile) {
+	v.Remove(path)
+	v.addFile(path, v.RelPath(path))
+	if note != nil {
+		v.notes = append(v.notes, note)
+		v.addNote(note)
+	}
+	v.indexAliases()
+	v.names = nil
+}
+
+// Remove removes the file at path, or all files below path if it is a folder
+func (v *VaultIndex) Remove(path string) {
+	removed := func(file string) bool {
+		return file == path || strings.HasPrefix(file, path+string(filepath.Separator))
+	}
+
+	for normalized, file := range v.paths {
+		if !removed(file) {
+			continue
+		}
+		delete(v.paths, normalized)
+		delete(v.headings, file)
+		delete(v.blocks, file)
+
+		segments := strings.Split(normalized, "/")
+		for i := range segments {
+			suffix := strings.Join(segments[i:], "/")
+			files := slices.DeleteFunc(v.files[suffix], removed)
+			if len(files) == 0 {
+				delete(v.files, suffix)
+			} else {
+				v.files[suffix] = files
+			}
+		}
+	}
+
+	v.notes = slices.DeleteFunc(v.notes, func(note *model.ParsedFile) bool {
+		return removed(note.Path)
+	})
+	for path := range v.notePaths {
+		if removed(path) {
+			delete(v.notePaths, path)
+		}
+	}
+	v.indexAliases()
+	v.names = nil
+}
+
+// addFile indexes a file by normalized vault-relative path and by every path
+// suffix, from full path down to basename, so [[Note]], [[Sub/Note]] and
+// [[Folder/Sub/Note]] all resolve
+func (v *VaultIndex) addFile(path string, relPath string) {
+	normalized := normalizePath(relPath)
+	v.paths[normalized] = path
+
+	segments := strings.Split(normalized, "/")
+	for i := range segments {
+		suffix := strings.Join(segments[i:], "/")
+		v.files[suffix] = append(v.files[suffix], path)
+	}
+}
+
+// addNote indexes the path, headings and block IDs of a parsed note
+func (v *VaultIndex) addNote(note *model.ParsedFile) {
+	v.notePaths[note.Path] = note
+
+	v.headings[note.Path] = make(map[string]struct{}, len(note.Headings))
+	for _, heading := range note.Headings {
+		v.headings[note.Path][normalizeHeading(heading)] = struct{}{}
+	}
+
+	v.blocks[note.Path] = make(map[string]struct{}, len(note.BlockIDs))
+	for _, blockID := range note.BlockIDs {
+		v.blocks[note.Path][strings.ToLower(blockID)] = struct{}{}
+	}
+}
+
+// indexAliases rebuilds the aliases of all notes in note order, so duplicate
+// aliases always resolve to the same note
+func (v *VaultIndex) indexAliases() {
+	v.aliases = make(map[string]string)
+	v.aliasNames = make(map[string]string)
+	for _, note := range v.notes {
+		for _, alias := range note.Aliases {
+			normalizedAlias := normalizeTarget(alias)
+			v.aliases[normalizedAlias] = note.Path
+			v.aliasNames[normalizedAlias] = alias
+		}
+	}
 }
 
 // Resolve checks if a target exists in the index (case-insensitive)
 func (v *VaultIndex) Resolve(target string) bool {
-	normalized := normalizeTarget(target)
+	_, exists := v.Path(target)
+	return exists
+}
+
+// Path returns the absolute path a target resolves to (case-insensitive).
+// The target may be a filename, a vault-relative path or a partial path suffix.
+// If several files match, the first candidate is returned.
+func (v *VaultIndex) Path(target string) (string, bool) {
+	candidates := v.Candidates(target)
+	if len(candidates) == 0 {
+		return "", false
+	}
+	return candidates[0], true
+}
+
+// Candidates returns all files a target matches (case-insensitive). A file at
+// the exact vault-relative path comes first, followed by all files matching the
+// target as a path suffix in vault order. /Folder/Note only matches from the
+// vault root. Aliases are only considered if no file matches.
+func (v *VaultIndex) Candidates(target string) []string {
+	normalized := normalizePath(target)
+	exact, hasExact := v.paths[normalized]
 
-	// Check files first
-	if _, exists := v.files[normalized]; exists {
-		return true
+	var candidates []string
+	if hasExact {
+		candidates = append(candidates, exact)
+	}
+
+	// Check filenames and partial paths
+	if !strings.HasPrefix(target, "/") {
+		for _, path := range v.files[normalized] {
+			if path != exact {
+				candidates = append(candidates, path)
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates
 	}
 
 	// Check aliases
-	if _, exists := v.aliases[normalized]; exists {
-		return true
+	if path, exists := v.aliases[normalizeTarget(target)]; exists {
+		return []string{path}
+	}
+
+	return nil
+}
+
+// PathFrom resolves a target as seen from the linking file at source. Paths are
+// tried relative to the linking file's directory first, then like Path.
+// Targets starting with ./ or ../ only resolve relative to the linking file.
+func (v *VaultIndex) PathFrom(source string, target string) (string, bool) {
+	if path, exists := v.Relative(source, target); exists {
+		return path, true
+	}
+
+	if IsRelative(target) {
+		return "", false
+	}
+
+	return v.Path(target)
+}
+
+// Relative looks up a target path relative to the directory of the linking file
+func (v *VaultIndex) Relative(source string, target string) (string, bool) {
+	if strings.HasPrefix(target, "/") {
+		return "", false
+	}
+
+	relPath, err := filepath.Rel(v.vaultPath, filepath.Join(filepath.Dir(source), target))
+	if err != nil || relPath == ".." || strings.HasPrefix(filepath.ToSlash(relPath), "../") {
+		return "", false
+	}
+
+	resolved, exists := v.paths[normalizePath(relPath)]
+	return resolved, exists
+}
+
+// LinksTo returns true if link targets the file at path by name, path suffix or
+// relative path. Unlike PathFrom it does not need the file to exist, so links to
+// deleted or renamed files can be found. Aliases are not considered.
+func (v *VaultIndex) LinksTo(link *model.Link, path string) bool {
+	relPath := normalizePath(v.RelPath(path))
+	if link.IsMarkdown || IsRelative(link.Target) {
+		joined := filepath.Join(filepath.Dir(link.Source), link.Target)
+		if normalizePath(v.RelPath(joined)) == relPath {
+			return true
+		}
+		if IsRelative(link.Target) {
+			return false
+		}
+	}
+
+	target := normalizePath(link.Target)
+	if target == "" {
+		return false
+	}
+	if strings.HasPrefix(link.Target, "/") {
+		return target == relPath
+	}
+	return target == relPath || strings.HasSuffix(relPath, "/"+target)
+}
+
+// RelPath returns path relative to the vault root with forward slashes
+func (v *VaultIndex) RelPath(path string) string {
+	relPath, err := filepath.Rel(v.vaultPath, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// Files returns the absolute paths of all indexed files, sorted
+func (v *VaultIndex) Files() []string {
+	files := make([]string, 0, len(v.paths))
+	for _, path := range v.paths {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// VaultPath returns the vault directory the index was built for
+func (v *VaultIndex) VaultPath() string {
+	return v.vaultPath
+}
+
+// Notes returns the parsed markdown files in the order they were passed to Build
+func (v *VaultIndex) Notes() []*model.ParsedFile {
+	return v.notes
+}
+
+// Note returns the parsed markdown file at path
+func (v *VaultIndex) Note(path string) (*model.ParsedFile, bool) {
+	note, exists := v.notePaths[path]
+	return note, exists
+}
+
+// Name is a link target known in the vault
+type Name struct {
+	Name    string // note name without .md, file name with extension or alias
+	Path    string // absolute path the name opens
+	IsAlias bool
+}
+
+// Names returns the names of all indexed notes and files and all aliases, sorted by
+// name. The list is built once until the index changes and must not be modified.
+func (v *VaultIndex) Names() []Name {
+	if v.names != nil {
+		return v.names
 	}
+	names := make([]Name, 0, len(v.paths)+len(v.aliasNames))
+	for _, path := range v.paths {
+		name := filepath.Base(path)
+		if v.IsNote(path) {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+		names = append(names, Name{Name: name, Path: path})
+	}
+	for normalized, alias := range v.aliasNames {
+		names = append(names, Name{Name: alias, Path: v.aliases[normalized], IsAlias: true})
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Name != names[j].Name {
+			return names[i].Name < names[j].Name
+		}
+		return names[i].Path < names[j].Path
+	})
+	v.names = names
+	return names
+}
+
+// IsNote returns true if path is a markdown note whose headings were indexed
+func (v *VaultIndex) IsNote(path string) bool {
+	_, exists := v.headings[path]
+	return exists
+}
+
+// HasHeading checks if the note at path contains heading, using Obsidian's
+// case-insensitive and whitespace-tolerant matching
+func (v *VaultIndex) HasHeading(path string, heading string) bool {
+	_, exists := v.headings[path][normalizeHeading(heading)]
+	return exists
+}
+
+// HasBlock checks if the note at path contains a ^blockID marker (case-insensitive)
+func (v *VaultIndex) HasBlock(path string, blockID string) bool {
+	_, exists := v.blocks[path][strings.ToLower(blockID)]
+	return exists
+}
 
-	return false
+// IsRelative returns true for targets starting with ./ or ../
+func IsRelative(target string) bool {
+	return strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../")
 }
 
 // normalizeTarget converts a target to normalized form for case-insensitive matching
@@ -118,3 +424,24 @@ func normalizeTarget(target string) string {
 	// Convert to lowercase for case-insensitive matching
 	return strings.ToLower(target)
 }
+
+// normalizePath converts a path to normalized form with forward slashes and
+// no leading slash for case-insensitive matching
+func normalizePath(path string) string {
+	return normalizeTarget(strings.TrimPrefix(filepath.ToSlash(path), "/"))
+}
+
+// normalizeHeading converts a heading to the form Obsidian uses for anchor matching.
+// Characters that cannot appear in a link anchor are treated as whitespace and
+// runs of whitespace are collapsed.
+func normalizeHeading(heading string) string {
+	heading = strings.Map(func(r rune) rune {
+		switch r {
+		case '#', '|', '^', ':', '[', ']':
+			return ' '
+		}
+		return r
+	}, heading)
+
+	return strings.ToLower(strings.Join(strings.Fields(heading), " "))
+}
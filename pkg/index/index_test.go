@@ -12,7 +12,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
 	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
 )
 
@@ -28,7 +30,7 @@ var _ = Describe("IndexBuilder", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 		p = parser.New()
-		builder = index.New(p)
+		builder = index.New(p, exclude.New(nil, nil), 0)
 
 		tempDir, err = os.MkdirTemp("", "index-test")
 		Expect(err).NotTo(HaveOccurred())
@@ -72,6 +74,40 @@ var _ = Describe("IndexBuilder", func() {
 			Expect(idx.Resolve("mynote")).To(BeTrue())
 		})
 
+		It("keeps the parsed notes in file order", func() {
+			var files []string
+			for _, name := range []string{"C.md", "A.md", "B.md"} {
+				file := filepath.Join(tempDir, name)
+				Expect(os.WriteFile(file, []byte("[[Target]]"), 0600)).To(Succeed())
+				files = append(files, file)
+			}
+
+			idx, err := index.New(p, exclude.New(nil, nil), 2).Build(ctx, tempDir, files)
+			Expect(err).NotTo(HaveOccurred())
+
+			notes := idx.Notes()
+			Expect(notes).To(HaveLen(3))
+			for i, note := range notes {
+				Expect(note.Path).To(Equal(files[i]))
+				Expect(note.Links).To(HaveLen(1))
+			}
+		})
+
+		It("returns error if a note cannot be read", func() {
+			_, err := builder.Build(ctx, tempDir, []string{filepath.Join(tempDir, "missing.md")})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("returns error if ctx is canceled", func() {
+			file := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(file, []byte("content"), 0600)).To(Succeed())
+
+			ctx, cancel := context.WithCancel(ctx)
+			cancel()
+			_, err := builder.Build(ctx, tempDir, []string{file})
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("indexes aliases from YAML frontmatter", func() {
 			content := `---
 aliases: [AI, Artificial Intelligence]
@@ -132,5 +168,275 @@ Note content`
 			Expect(idx.Resolve("File With Spaces")).To(BeTrue())
 			Expect(idx.Resolve("file with spaces")).To(BeTrue())
 		})
+
+		It("indexes headings per note", func() {
+			file := filepath.Join(tempDir, "Plan.md")
+			content := "# Project Plan\n\n## Milestones: 2025\n"
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{file})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idx.IsNote(file)).To(BeTrue())
+			Expect(idx.HasHeading(file, "Project Plan")).To(BeTrue())
+			Expect(idx.HasHeading(file, "project plan")).To(BeTrue())
+			Expect(idx.HasHeading(file, "Milestones 2025")).To(BeTrue())
+			Expect(idx.HasHeading(file, "Renamed")).To(BeFalse())
+		})
+
+		It("indexes block IDs per note", func() {
+			file := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(file, []byte("Important paragraph. ^key-point\n"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{file})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idx.HasBlock(file, "key-point")).To(BeTrue())
+			Expect(idx.HasBlock(file, "deleted")).To(BeFalse())
+		})
+
+		It("resolves paths relative to the source file and vault root", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Projects", "2024"), 0755)).To(Succeed())
+			source := filepath.Join(tempDir, "Projects", "2024", "Plan.md")
+			target := filepath.Join(tempDir, "Projects", "Overview.md")
+			Expect(os.WriteFile(source, []byte("content"), 0600)).To(Succeed())
+			Expect(os.WriteFile(target, []byte("content"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{source, target})
+			Expect(err).NotTo(HaveOccurred())
+
+			path, exists := idx.PathFrom(source, "../Overview.md")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(target))
+
+			path, exists = idx.PathFrom(source, "Projects/Overview.md")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(target))
+
+			path, exists = idx.PathFrom(source, "/projects/overview")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(target))
+
+			_, exists = idx.PathFrom(source, "Missing.md")
+			Expect(exists).To(BeFalse())
+		})
+
+		It("resolves full and partial vault paths", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Projects", "2024"), 0755)).To(Succeed())
+			file := filepath.Join(tempDir, "Projects", "2024", "Plan.md")
+			Expect(os.WriteFile(file, []byte("content"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{file})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, target := range []string{
+				"Plan", "2024/Plan", "Projects/2024/Plan", "projects/2024/plan.md", "/Projects/2024/Plan",
+			} {
+				path, exists := idx.Path(target)
+				Expect(exists).To(BeTrue(), target)
+				Expect(path).To(Equal(file), target)
+			}
+
+			Expect(idx.Resolve("Other/Plan")).To(BeFalse())
+			Expect(idx.Resolve("jects/2024/Plan")).To(BeFalse())
+			Expect(idx.Resolve("/2024/Plan")).To(BeFalse())
+		})
+
+		It("resolves ./ and ../ only relative to the linking file", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "A", "B"), 0755)).To(Succeed())
+			source := filepath.Join(tempDir, "A", "B", "Source.md")
+			sibling := filepath.Join(tempDir, "A", "Sibling.md")
+			Expect(os.WriteFile(source, []byte("content"), 0600)).To(Succeed())
+			Expect(os.WriteFile(sibling, []byte("content"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{source, sibling})
+			Expect(err).NotTo(HaveOccurred())
+
+			path, exists := idx.PathFrom(source, "../Sibling")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(sibling))
+
+			path, exists = idx.PathFrom(source, "./Source")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(source))
+
+			_, exists = idx.PathFrom(source, "./Sibling")
+			Expect(exists).To(BeFalse())
+			_, exists = idx.PathFrom(source, "../../../Outside")
+			Expect(exists).To(BeFalse())
+		})
+
+		It("keeps all candidates for duplicate basenames", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "A"), 0755)).To(Succeed())
+			root := filepath.Join(tempDir, "Meeting.md")
+			nested := filepath.Join(tempDir, "A", "Meeting.md")
+			Expect(os.WriteFile(root, []byte("content"), 0600)).To(Succeed())
+			Expect(os.WriteFile(nested, []byte("content"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{root, nested})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idx.Candidates("Meeting")).To(Equal([]string{root, nested}))
+			Expect(idx.Candidates("A/Meeting")).To(Equal([]string{nested}))
+			Expect(idx.Candidates("/Meeting")).To(Equal([]string{root}))
+			Expect(idx.RelPath(nested)).To(Equal("A/Meeting.md"))
+		})
+
+		It("skips excluded paths", func() {
+			builder = index.New(p, exclude.New(nil, []string{"Archive"}), 0)
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Archive"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".trash"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "Archive", "Old.md"), []byte(""), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, ".trash", "Deleted.md"), []byte(""), 0600)).
+				To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(idx.Resolve("Old")).To(BeFalse())
+			Expect(idx.Resolve("Deleted")).To(BeFalse())
+		})
+
+		It("returns path for resolved target", func() {
+			file := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(file, []byte("content"), 0600)).To(Succeed())
+
+			idx, err := builder.Build(ctx, tempDir, []string{file})
+			Expect(err).NotTo(HaveOccurred())
+
+			path, exists := idx.Path("note")
+			Expect(exists).To(BeTrue())
+			Expect(path).To(Equal(file))
+		})
+	})
+
+	Context("Add and Remove", func() {
+		var (
+			idx  *index.VaultIndex
+			note string
+		)
+
+		BeforeEach(func() {
+			note = filepath.Join(tempDir, "Folder", "Note.md")
+			Expect(os.MkdirAll(filepath.Dir(note), 0750)).To(Succeed())
+			Expect(os.WriteFile(note, []byte("# Title"), 0600)).To(Succeed())
+
+			idx, err = builder.Build(ctx, tempDir, []string{note})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds a note with aliases and headings", func() {
+			added := filepath.Join(tempDir, "Added.md")
+			idx.Add(added, &model.ParsedFile{
+				Path:     added,
+				Aliases:  []string{"Short"},
+				Headings: []string{"Part"},
+			})
+
+			Expect(idx.Resolve("Added")).To(BeTrue())
+			Expect(idx.Resolve("Short")).To(BeTrue())
+			Expect(idx.HasHeading(added, "Part")).To(BeTrue())
+			Expect(idx.IsNote(added)).To(BeTrue())
+			Expect(idx.Notes()).To(HaveLen(2))
+		})
+
+		It("adds an attachment", func() {
+			image := filepath.Join(tempDir, "image.png")
+			idx.Add(image, nil)
+
+			Expect(idx.Resolve("image.png")).To(BeTrue())
+			Expect(idx.IsNote(image)).To(BeFalse())
+			Expect(idx.Notes()).To(HaveLen(1))
+		})
+
+		It("replaces a changed note", func() {
+			idx.Add(note, &model.ParsedFile{Path: note, Headings: []string{"Other"}})
+
+			Expect(idx.HasHeading(note, "Title")).To(BeFalse())
+			Expect(idx.HasHeading(note, "Other")).To(BeTrue())
+			Expect(idx.Notes()).To(HaveLen(1))
+			parsed, exists := idx.Note(note)
+			Expect(exists).To(BeTrue())
+			Expect(parsed.Headings).To(Equal([]string{"Other"}))
+			Expect(idx.Candidates("Note")).To(Equal([]string{note}))
+		})
+
+		It("removes a note", func() {
+			idx.Remove(note)
+
+			Expect(idx.Resolve("Note")).To(BeFalse())
+			Expect(idx.Resolve("Folder/Note")).To(BeFalse())
+			Expect(idx.IsNote(note)).To(BeFalse())
+			Expect(idx.Notes()).To(BeEmpty())
+			Expect(idx.Files()).To(BeEmpty())
+			_, exists := idx.Note(note)
+			Expect(exists).To(BeFalse())
+		})
+
+		It("updates the names after adding and removing files", func() {
+			Expect(idx.Names()).To(Equal([]index.Name{{Name: "Note", Path: note}}))
+
+			added := filepath.Join(tempDir, "Added.md")
+			idx.Add(added, &model.ParsedFile{Path: added, Aliases: []string{"Short"}})
+			Expect(idx.Names()).To(Equal([]index.Name{
+				{Name: "Added", Path: added},
+				{Name: "Note", Path: note},
+				{Name: "Short", Path: added, IsAlias: true},
+			}))
+
+			idx.Remove(note)
+			Expect(idx.Names()).To(Equal([]index.Name{
+				{Name: "Added", Path: added},
+				{Name: "Short", Path: added, IsAlias: true},
+			}))
+		})
+
+		It("removes all files of a folder", func() {
+			idx.Remove(filepath.Join(tempDir, "Folder"))
+
+			Expect(idx.Resolve("Note")).To(BeFalse())
+			Expect(idx.Notes()).To(BeEmpty())
+		})
+	})
+
+	Context("LinksTo", func() {
+		var (
+			idx    *index.VaultIndex
+			source string
+			target string
+		)
+
+		BeforeEach(func() {
+			source = filepath.Join(tempDir, "Notes", "Source.md")
+			target = filepath.Join(tempDir, "Projects", "Plan.md")
+			Expect(os.MkdirAll(filepath.Dir(source), 0750)).To(Succeed())
+			Expect(os.WriteFile(source, []byte("content"), 0600)).To(Succeed())
+
+			idx, err = builder.Build(ctx, tempDir, []string{source})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("matches links to a file that does not exist",
+			func(link model.Link, expected bool) {
+				link.Source = source
+				Expect(idx.LinksTo(&link, target)).To(Equal(expected))
+			},
+			Entry("name", model.Link{Target: "Plan"}, true),
+			Entry("name with different case", model.Link{Target: "plan.md"}, true),
+			Entry("path", model.Link{Target: "Projects/Plan"}, true),
+			Entry("absolute path", model.Link{Target: "/Projects/Plan"}, true),
+			Entry("relative path", model.Link{Target: "../Projects/Plan"}, true),
+			Entry(
+				"markdown link",
+				model.Link{Target: "../Projects/Plan.md", IsMarkdown: true},
+				true,
+			),
+			Entry("other name", model.Link{Target: "Other"}, false),
+			Entry("partial name", model.Link{Target: "lan"}, false),
+			Entry("wrong folder", model.Link{Target: "Archive/Plan"}, false),
+			Entry("wrong relative path", model.Link{Target: "./Plan"}, false),
+			Entry("heading in the same note", model.Link{Heading: "Plan"}, false),
+		)
 	})
 })
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+// completion proposes note names and aliases after [[ and headings or block
+// IDs after [[Note#
+func (s *session) completion(params TextDocumentPositionParams) []CompletionItem {
+	path := uriToPath(params.TextDocument.URI)
+	lines := strings.Split(s.documents[path], "\n")
+	if params.Position.Line >= len(lines) {
+		return []CompletionItem{}
+	}
+	prefix := utf16Prefix(lines[params.Position.Line], params.Position.Character)
+
+	start := strings.LastIndex(prefix, "[[")
+	if start < 0 || strings.ContainsAny(prefix[start+len("[["):], "]|") {
+		return []CompletionItem{}
+	}
+	query := prefix[start+len("[["):]
+	if hash := strings.Index(query, "#"); hash >= 0 {
+		return s.anchorCompletion(path, params.Position, query[:hash], query[hash+1:])
+	}
+
+	replace := Range{
+		Start: Position{Line: params.Position.Line, Character: utf16Len(prefix[:start+len("[[")])},
+		End:   params.Position,
+	}
+	items := []CompletionItem{}
+	for _, name := range s.idx.Names() {
+		item := CompletionItem{
+			Label:    name.Name,
+			Kind:     completionKindFile,
+			Detail:   s.idx.RelPath(name.Path),
+			TextEdit: &TextEdit{Range: replace, NewText: name.Name},
+		}
+		if name.IsAlias {
+			item.Kind = completionKindReference
+			item.Detail = "alias of " + item.Detail
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// anchorCompletion proposes the headings, or block IDs after ^, of the target note
+func (s *session) anchorCompletion(
+	path string,
+	position Position,
+	target string,
+	anchor string,
+) []CompletionItem {
+	if target != "" {
+		var exists bool
+		if path, exists = s.idx.PathFrom(path, target); !exists {
+			return []CompletionItem{}
+		}
+	}
+	note, exists := s.idx.Note(path)
+	if !exists {
+		return []CompletionItem{}
+	}
+
+	anchors := note.Headings
+	prefix := ""
+	if strings.HasPrefix(anchor, "^") {
+		anchors = note.BlockIDs
+		prefix = "^"
+	}
+	replace := Range{
+		Start: Position{
+			Line:      position.Line,
+			Character: position.Character - utf16Len(anchor) + len(prefix),
+		},
+		End: position,
+	}
+	items := []CompletionItem{}
+	for _, a := range anchors {
+		items = append(items, CompletionItem{
+			Label:    prefix + a,
+			Kind:     completionKindReference,
+			TextEdit: &TextEdit{Range: replace, NewText: a},
+		})
+	}
+	return items
+}
+
+// definition returns the file the link at the position opens, or all
+// candidates of an ambiguous link
+func (s *session) definition(ctx context.Context, params TextDocumentPositionParams) []Location {
+	link := s.linkAt(uriToPath(params.TextDocument.URI), params.Position)
+	if link == nil {
+		return []Location{}
+	}
+	resolved := s.resolver.Resolve(ctx, link, s.idx)
+	targets := resolved.Candidates
+	if resolved.Path != "" {
+		targets = []string{resolved.Path}
+	}
+	locations := []Location{}
+	for _, target := range targets {
+		locations = append(locations, Location{URI: pathToURI(target)})
+	}
+	return locations
+}
+
+// references returns all links to the note the link at the position opens, or
+// to the current note if the position is not on a link
+func (s *session) references(ctx context.Context, params TextDocumentPositionParams) []Location {
+	target := uriToPath(params.TextDocument.URI)
+	if link := s.linkAt(target, params.Position); link != nil {
+		resolved := s.resolver.Resolve(ctx, link, s.idx)
+		if resolved.Path == "" {
+			return []Location{}
+		}
+		target = resolved.Path
+	}
+
+	locations := []Location{}
+	for _, note := range s.idx.Notes() {
+		for _, link := range note.Links {
+			resolved := s.resolver.Resolve(ctx, link, s.idx)
+			if resolved.Path == target || slices.Contains(resolved.Candidates, target) {
+				locations = append(locations, Location{
+					URI:   pathToURI(note.Path),
+					Range: linkRange(link),
+				})
+			}
+		}
+	}
+	return locations
+}
+
+// codeActions offers to replace broken links in the range with their suggestions.
+// The confident match used by --fix is the preferred action.
+func (s *session) codeActions(params CodeActionParams) []CodeAction {
+	path := uriToPath(params.TextDocument.URI)
+	note, exists := s.idx.Note(path)
+	if !exists {
+		return []CodeAction{}
+	}
+
+	actions := []CodeAction{}
+	for _, finding := range s.result.BrokenLinks[path] {
+		line := finding.Line - 1
+		if line < params.Range.Start.Line || line > params.Range.End.Line {
+			continue
+		}
+		link := linkAtOffset(note, finding.Offset)
+		if link == nil {
+			continue
+		}
+		for _, suggestion := range finding.Suggestions {
+			newText := link.WithTarget(suggestion)
+			actions = append(actions, CodeAction{
+				Title:       "Replace with " + newText,
+				Kind:        "quickfix",
+				IsPreferred: newText == finding.Fix,
+				Edit: WorkspaceEdit{Changes: map[string][]TextEdit{
+					params.TextDocument.URI: {{Range: linkRange(link), NewText: newText}},
+				}},
+			})
+		}
+	}
+	return actions
+}
+
+// linkAt returns the link of the note at path covering the position
+func (s *session) linkAt(path string, position Position) *model.Link {
+	note, exists := s.idx.Note(path)
+	if !exists {
+		return nil
+	}
+	for _, link := range note.Links {
+		r := linkRange(link)
+		if r.Start.Line == position.Line &&
+			r.Start.Character <= position.Character &&
+			position.Character < r.End.Character {
+			return link
+		}
+	}
+	return nil
+}
+
+// linkAtOffset returns the link of note starting at the byte offset
+func linkAtOffset(note *model.ParsedFile, offset int) *model.Link {
+	for _, link := range note.Links {
+		if link.Offset == offset {
+			return link
+		}
+	}
+	return nil
+}
+
+// utf16Prefix returns the start of line up to a UTF-16 character offset
+func utf16Prefix(line string, character int) string {
+	units := 0
+	for i, r := range line {
+		if units >= character {
+			return line[:i]
+		}
+		units += utf16.RuneLen(r)
+	}
+	return line
+}
+
+// utf16Len returns the length of s in UTF-16 code units
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
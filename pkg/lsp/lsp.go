@@ -0,0 +1,370 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/formatter"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/resolver"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/validator"
+)
+
+//counterfeiter:generate -o ../../mocks/lsp_server.go --fake-name LSPServer . Server
+
+// Server is a language server for the notes of a vault
+type Server interface {
+	// Serve answers LSP requests read from in until the client sends exit
+	Serve(ctx context.Context, vaultPath string, in io.Reader, out io.Writer) error
+}
+
+// New creates a new Server
+func New(
+	scanner scanner.Scanner,
+	parser parser.Parser,
+	indexBuilder index.Builder,
+	resolver resolver.Resolver,
+	validator validator.Validator,
+) Server {
+	return &server{
+		scanner:      scanner,
+		parser:       parser,
+		indexBuilder: indexBuilder,
+		resolver:     resolver,
+		validator:    validator,
+	}
+}
+
+type server struct {
+	scanner      scanner.Scanner
+	parser       parser.Parser
+	indexBuilder index.Builder
+	resolver     resolver.Resolver
+	validator    validator.Validator
+}
+
+// session is the state of one client connection
+type session struct {
+	*server
+	out       io.Writer
+	idx       *index.VaultIndex
+	documents map[string]string       // path -> text of documents open in the editor
+	result    *model.ValidationResult // findings of the open documents
+}
+
+// Serve indexes the vault and handles requests one at a time. Open documents
+// replace the files on disk in the index until they are closed.
+func (s *server) Serve(ctx context.Context, vaultPath string, in io.Reader, out io.Writer) error {
+	files, err := s.scanner.Scan(ctx, vaultPath)
+	if err != nil {
+		return errors.Wrap(ctx, err, "scan failed")
+	}
+	idx, err := s.indexBuilder.Build(ctx, vaultPath, files)
+	if err != nil {
+		return errors.Wrap(ctx, err, "build index failed")
+	}
+
+	sess := &session{
+		server:    s,
+		out:       out,
+		idx:       idx,
+		documents: make(map[string]string),
+		result:    &model.ValidationResult{BrokenLinks: make(map[string][]model.BrokenLink)},
+	}
+	reader := bufio.NewReader(in)
+	for {
+		req, err := readMessage(ctx, reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if err := sess.handle(ctx, req); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a request or notification and writes the response
+func (s *session) handle(ctx context.Context, req *request) error {
+	result, err := s.dispatch(ctx, req)
+	if len(req.ID) == 0 {
+		// Notifications have no response, failures are not reported back
+		return nil
+	}
+
+	res := response{JSONRPC: "2.0", ID: req.ID, Error: err}
+	if err == nil {
+		content, err := json.Marshal(result)
+		if err != nil {
+			return errors.Wrap(ctx, err, "marshal result failed")
+		}
+		res.Result = content
+	}
+	return writeMessage(ctx, s.out, res)
+}
+
+// dispatch runs the handler of a method
+func (s *session) dispatch(ctx context.Context, req *request) (interface{}, *responseError) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(), nil
+	case "shutdown", "initialized", "$/cancelRequest", "textDocument/didSave":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		return decode(req, &params, func() (interface{}, error) {
+			return nil, s.open(ctx, params.TextDocument.URI, params.TextDocument.Text)
+		})
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		return decode(req, &params, func() (interface{}, error) {
+			if len(params.ContentChanges) == 0 {
+				return nil, nil
+			}
+			text := params.ContentChanges[len(params.ContentChanges)-1].Text
+			return nil, s.open(ctx, params.TextDocument.URI, text)
+		})
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		return decode(req, &params, func() (interface{}, error) {
+			return nil, s.close(ctx, params.TextDocument.URI)
+		})
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		return decode(req, &params, func() (interface{}, error) {
+			return s.completion(params), nil
+		})
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		return decode(req, &params, func() (interface{}, error) {
+			return s.definition(ctx, params), nil
+		})
+	case "textDocument/references":
+		var params TextDocumentPositionParams
+		return decode(req, &params, func() (interface{}, error) {
+			return s.references(ctx, params), nil
+		})
+	case "textDocument/codeAction":
+		var params CodeActionParams
+		return decode(req, &params, func() (interface{}, error) {
+			return s.codeActions(params), nil
+		})
+	default:
+		return nil, &responseError{
+			Code:    codeMethodNotFound,
+			Message: "method not found: " + req.Method,
+		}
+	}
+}
+
+// decode unmarshals the params of req and calls fn
+func decode(
+	req *request,
+	params interface{},
+	fn func() (interface{}, error),
+) (interface{}, *responseError) {
+	if err := json.Unmarshal(req.Params, params); err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	result, err := fn()
+	if err != nil {
+		return nil, &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return result, nil
+}
+
+// initialize returns the server capabilities
+func (s *session) initialize() interface{} {
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync": 1, // full document on every change
+			"completionProvider": map[string]interface{}{
+				"triggerCharacters": []string{"[", "#"},
+			},
+			"definitionProvider": true,
+			"referencesProvider": true,
+			"codeActionProvider": true,
+		},
+		"serverInfo": map[string]string{"name": "obsidian-lint"},
+	}
+}
+
+// open parses the text of an opened or changed document into the index
+func (s *session) open(ctx context.Context, uri string, text string) error {
+	path := uriToPath(uri)
+	if filepath.Ext(path) != ".md" {
+		return nil
+	}
+	parsed, err := s.parser.ParseContent(ctx, path, text)
+	if err != nil {
+		return err
+	}
+	s.documents[path] = text
+	return s.update(ctx, path, parsed)
+}
+
+// close replaces a closed document with the file on disk and clears its diagnostics
+func (s *session) close(ctx context.Context, uri string) error {
+	path := uriToPath(uri)
+	if _, exists := s.documents[path]; !exists {
+		return nil
+	}
+	delete(s.documents, path)
+	delete(s.result.BrokenLinks, path)
+
+	if err := writeMessage(ctx, s.out, notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}},
+	}); err != nil {
+		return err
+	}
+
+	parsed, err := s.parser.Parse(ctx, path)
+	if err != nil {
+		// Closed without saving a new note
+		return s.update(ctx, path, nil)
+	}
+	return s.update(ctx, path, parsed)
+}
+
+// update replaces the note at path in the index, or removes it if note is nil, and
+// publishes the diagnostics of the changed document and of the open documents whose
+// links to it changed. Links to it before and after the change are both affected,
+// e.g. by a removed alias or a renamed heading.
+func (s *session) update(ctx context.Context, path string, note *model.ParsedFile) error {
+	affected := s.linking(ctx, path)
+	if note != nil {
+		s.idx.Add(path, note)
+	} else {
+		s.idx.Remove(path)
+	}
+	for document := range s.linking(ctx, path) {
+		affected[document] = struct{}{}
+	}
+	if _, open := s.documents[path]; open {
+		affected[path] = struct{}{}
+	}
+
+	paths := make([]string, 0, len(affected))
+	for document := range affected {
+		paths = append(paths, document)
+	}
+	sort.Strings(paths)
+	return s.publishDiagnostics(ctx, paths)
+}
+
+// linking returns the open documents with a link to path, by name or resolving to it
+// through an alias
+func (s *session) linking(ctx context.Context, path string) map[string]struct{} {
+	result := make(map[string]struct{})
+	for document := range s.documents {
+		note, exists := s.idx.Note(document)
+		if !exists || document == path {
+			continue
+		}
+		for _, link := range note.Links {
+			resolved := s.resolver.Resolve(ctx, link, s.idx)
+			if s.idx.LinksTo(link, path) || resolved.Path == path ||
+				slices.Contains(resolved.Candidates, path) {
+				result[document] = struct{}{}
+				break
+			}
+		}
+	}
+	return result
+}
+
+// publishDiagnostics validates the open documents at paths and sends their findings
+func (s *session) publishDiagnostics(ctx context.Context, paths []string) error {
+	result, err := s.validator.ValidateNotes(ctx, s.idx, paths)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		s.result.BrokenLinks[path] = result.BrokenLinks[path]
+		diagnostics := []Diagnostic{}
+		for _, link := range result.BrokenLinks[path] {
+			diagnostics = append(diagnostics, diagnostic(link))
+		}
+		if err := writeMessage(ctx, s.out, notification{
+			JSONRPC: "2.0",
+			Method:  "textDocument/publishDiagnostics",
+			Params:  PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: diagnostics},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diagnostic converts a finding, file-level findings cover the first line
+func diagnostic(link model.BrokenLink) Diagnostic {
+	d := Diagnostic{
+		Range:    findingRange(link),
+		Severity: severityError,
+		Code:     string(link.Kind),
+		Source:   "obsidian-lint",
+		Message:  formatter.Message(link),
+	}
+	if link.Severity == model.SeverityWarning {
+		d.Severity = severityWarning
+	}
+	return d
+}
+
+// findingRange returns the editor range of a finding
+func findingRange(link model.BrokenLink) Range {
+	line := max(link.Line-1, 0)
+	if link.ColumnUTF16 == 0 {
+		return Range{Start: Position{Line: line}, End: Position{Line: line}}
+	}
+	return Range{
+		Start: Position{Line: line, Character: link.ColumnUTF16 - 1},
+		End:   Position{Line: line, Character: link.EndColumnUTF16 - 1},
+	}
+}
+
+// linkRange returns the editor range of a link
+func linkRange(link *model.Link) Range {
+	line := max(link.Line-1, 0)
+	return Range{
+		Start: Position{Line: line, Character: max(link.ColumnUTF16-1, 0)},
+		End:   Position{Line: line, Character: max(link.EndColumnUTF16-1, 0)},
+	}
+}
+
+// uriToPath converts a file URI to a path
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// pathToURI converts a path to a file URI
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/lsp"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/resolver"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
+	"github.com/bborbe/obsidian-lint/pkg/validator"
+)
+
+// message is a response or notification written by the server
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+var _ = Describe("Server", func() {
+	var (
+		ctx      context.Context
+		server   lsp.Server
+		tempDir  string
+		source   string
+		input    bytes.Buffer
+		messages []message
+		nextID   int
+		err      error
+	)
+
+	send := func(method string, params interface{}, withID bool) {
+		body := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+		if withID {
+			nextID++
+			body["id"] = nextID
+		}
+		content, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+	request := func(method string, params interface{}) int {
+		send(method, params, true)
+		return nextID
+	}
+	notify := func(method string, params interface{}) {
+		send(method, params, false)
+	}
+	uri := func(path string) string {
+		return "file://" + filepath.ToSlash(path)
+	}
+	position := func(path string, line int, character int) interface{} {
+		return map[string]interface{}{
+			"textDocument": map[string]string{"uri": uri(path)},
+			"position":     map[string]int{"line": line, "character": character},
+		}
+	}
+	open := func(path string, text string) {
+		notify("textDocument/didOpen", map[string]interface{}{
+			"textDocument": map[string]interface{}{
+				"uri": uri(path), "languageId": "markdown", "version": 1, "text": text,
+			},
+		})
+	}
+	serve := func() {
+		notify("exit", nil)
+		var output bytes.Buffer
+		Expect(server.Serve(ctx, tempDir, &input, &output)).To(Succeed())
+
+		messages = nil
+		reader := bufio.NewReader(&output)
+		for {
+			header, err := textproto.NewReader(reader).ReadMIMEHeader()
+			if err == io.EOF {
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			length, err := strconv.Atoi(header.Get("Content-Length"))
+			Expect(err).NotTo(HaveOccurred())
+			content := make([]byte, length)
+			_, err = io.ReadFull(reader, content)
+			Expect(err).NotTo(HaveOccurred())
+			var msg message
+			Expect(json.Unmarshal(content, &msg)).To(Succeed())
+			messages = append(messages, msg)
+		}
+	}
+	result := func(id int, value interface{}) {
+		for _, msg := range messages {
+			if msg.ID != nil && *msg.ID == id {
+				Expect(msg.Error).To(BeNil())
+				Expect(json.Unmarshal(msg.Result, value)).To(Succeed())
+				return
+			}
+		}
+		Fail(fmt.Sprintf("no response for request %d", id))
+	}
+	diagnostics := func(path string) []lsp.Diagnostic {
+		var last []lsp.Diagnostic
+		for _, msg := range messages {
+			if msg.Method != "textDocument/publishDiagnostics" {
+				continue
+			}
+			var params lsp.PublishDiagnosticsParams
+			Expect(json.Unmarshal(msg.Params, &params)).To(Succeed())
+			if params.URI == uri(path) {
+				last = params.Diagnostics
+			}
+		}
+		return last
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tempDir, err = os.MkdirTemp("", "lsp-test")
+		Expect(err).NotTo(HaveOccurred())
+		input.Reset()
+		nextID = 0
+
+		source = filepath.Join(tempDir, "Source.md")
+		Expect(os.WriteFile(source, []byte("[[Target]]"), 0600)).To(Succeed())
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "Target.md"),
+			[]byte("---\naliases: [Goal]\n---\n# Intro\n## Details\nText ^block1\n"),
+			0600,
+		)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "Other.md"), []byte("[[Target#Intro]]"), 0600)).
+			To(Succeed())
+
+		m := exclude.New(nil, nil)
+		s := scanner.New(m)
+		p := parser.New()
+		b := index.New(p, m, 0)
+		cfg := config.Default()
+		r := resolver.New(cfg)
+		v := validator.New(s, p, b, r, suggest.New(), schema.New(nil), cfg)
+		server = lsp.New(s, p, b, r, v)
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("announces its capabilities", func() {
+		id := request("initialize", map[string]interface{}{
+			"capabilities": map[string]interface{}{},
+		})
+		serve()
+
+		var initResult struct {
+			Capabilities map[string]interface{} `json:"capabilities"`
+		}
+		result(id, &initResult)
+		Expect(initResult.Capabilities).To(HaveKeyWithValue("definitionProvider", true))
+		Expect(initResult.Capabilities).To(HaveKeyWithValue("referencesProvider", true))
+		Expect(initResult.Capabilities).To(HaveKey("completionProvider"))
+	})
+
+	It("publishes diagnostics for the open document", func() {
+		open(source, "Line one\nsee [[Targt]] here")
+		serve()
+
+		found := diagnostics(source)
+		Expect(found).To(HaveLen(1))
+		Expect(found[0].Code).To(Equal("broken-link"))
+		Expect(found[0].Severity).To(Equal(1))
+		Expect(found[0].Range).To(Equal(lsp.Range{
+			Start: lsp.Position{Line: 1, Character: 4},
+			End:   lsp.Position{Line: 1, Character: 13},
+		}))
+		Expect(found[0].Message).To(ContainSubstring("did you mean: Target?"))
+	})
+
+	It("clears diagnostics when the document is fixed", func() {
+		open(source, "[[Targt]]")
+		notify("textDocument/didChange", map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": uri(source), "version": 2},
+			"contentChanges": []map[string]string{{"text": "[[Target]]"}},
+		})
+		serve()
+
+		Expect(diagnostics(source)).To(BeEmpty())
+	})
+
+	It("updates diagnostics of open documents linking to a changed note", func() {
+		target := filepath.Join(tempDir, "Target.md")
+		other := filepath.Join(tempDir, "Other.md")
+		open(other, "[[Target#Intro]]")
+		open(target, "# Renamed\n")
+		serve()
+
+		found := diagnostics(other)
+		Expect(found).To(HaveLen(1))
+		Expect(found[0].Code).To(Equal("broken-heading"))
+		Expect(diagnostics(target)).To(BeEmpty())
+		Expect(diagnostics(source)).To(BeNil())
+	})
+
+	It("updates diagnostics of open documents linking to a removed alias", func() {
+		open(source, "[[Goal]]")
+		open(filepath.Join(tempDir, "Target.md"), "# Intro\n")
+		serve()
+
+		found := diagnostics(source)
+		Expect(found).To(HaveLen(1))
+		Expect(found[0].Code).To(Equal("broken-link"))
+	})
+
+	It("completes note names and aliases after [[", func() {
+		open(source, "see [[Ta")
+		id := request("textDocument/completion", position(source, 0, 8))
+		serve()
+
+		var items []lsp.CompletionItem
+		result(id, &items)
+		var labels []string
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+		Expect(labels).To(ContainElements("Target", "Goal", "Other"))
+		for _, item := range items {
+			Expect(item.TextEdit.Range.Start).To(Equal(lsp.Position{Line: 0, Character: 6}))
+		}
+	})
+
+	It("completes headings and block IDs after #", func() {
+		open(source, "[[Target#\n[[Target#^")
+		headings := request("textDocument/completion", position(source, 0, 9))
+		blocks := request("textDocument/completion", position(source, 1, 10))
+		serve()
+
+		var items []lsp.CompletionItem
+		result(headings, &items)
+		Expect(items).To(HaveLen(2))
+		Expect(items[0].Label).To(Equal("Intro"))
+		Expect(items[1].Label).To(Equal("Details"))
+
+		result(blocks, &items)
+		Expect(items).To(HaveLen(1))
+		Expect(items[0].Label).To(Equal("^block1"))
+		Expect(items[0].TextEdit.NewText).To(Equal("block1"))
+	})
+
+	It("does not complete outside of links", func() {
+		open(source, "[[Target]] text")
+		id := request("textDocument/completion", position(source, 0, 14))
+		serve()
+
+		var items []lsp.CompletionItem
+		result(id, &items)
+		Expect(items).To(BeEmpty())
+	})
+
+	It("goes to the definition of a link", func() {
+		open(source, "[[Goal]]")
+		id := request("textDocument/definition", position(source, 0, 3))
+		serve()
+
+		var locations []lsp.Location
+		result(id, &locations)
+		Expect(locations).To(HaveLen(1))
+		Expect(locations[0].URI).To(Equal(uri(filepath.Join(tempDir, "Target.md"))))
+	})
+
+	It("finds all references to a note", func() {
+		target := filepath.Join(tempDir, "Target.md")
+		id := request("textDocument/references", position(target, 0, 0))
+		serve()
+
+		var locations []lsp.Location
+		result(id, &locations)
+		var uris []string
+		for _, location := range locations {
+			uris = append(uris, location.URI)
+		}
+		Expect(uris).To(ConsistOf(uri(source), uri(filepath.Join(tempDir, "Other.md"))))
+	})
+
+	It("offers suggestions as code actions", func() {
+		open(source, "![[Targt#Intro|Alias]]")
+		id := request("textDocument/codeAction", map[string]interface{}{
+			"textDocument": map[string]string{"uri": uri(source)},
+			"range": map[string]interface{}{
+				"start": map[string]int{"line": 0, "character": 0},
+				"end":   map[string]int{"line": 0, "character": 0},
+			},
+			"context": map[string]interface{}{"diagnostics": []interface{}{}},
+		})
+		serve()
+
+		var actions []lsp.CodeAction
+		result(id, &actions)
+		Expect(actions).NotTo(BeEmpty())
+		Expect(actions[0].IsPreferred).To(BeTrue())
+		edits := actions[0].Edit.Changes[uri(source)]
+		Expect(edits).To(HaveLen(1))
+		Expect(edits[0].NewText).To(Equal("![[Target#Intro|Alias]]"))
+	})
+
+	It("returns a null result for requests without result", func() {
+		id := request("shutdown", nil)
+		serve()
+
+		for _, msg := range messages {
+			if msg.ID != nil && *msg.ID == id {
+				Expect(msg.Error).To(BeNil())
+				Expect(string(msg.Result)).To(Equal("null"))
+				return
+			}
+		}
+		Fail("no response")
+	})
+
+	It("fails on a negative content length", func() {
+		input.WriteString("Content-Length: -1\r\n\r\n")
+		Expect(server.Serve(ctx, tempDir, &input, io.Discard)).To(HaveOccurred())
+	})
+
+	It("fails on a too large content length", func() {
+		input.WriteString("Content-Length: 1000000000000\r\n\r\n")
+		Expect(server.Serve(ctx, tempDir, &input, io.Discard)).To(HaveOccurred())
+	})
+
+	It("returns an error for unknown methods", func() {
+		id := request("workspace/unknown", map[string]interface{}{})
+		serve()
+
+		for _, msg := range messages {
+			if msg.ID != nil && *msg.ID == id {
+				Expect(msg.Error).NotTo(BeNil())
+				Expect(msg.Error.Code).To(Equal(-32601))
+				Expect(msg.Result).To(BeNil())
+				return
+			}
+		}
+		Fail("no response")
+	})
+})
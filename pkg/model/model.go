@@ -4,23 +4,262 @@
 
 package model
 
-// Link represents a wiki link found in a markdown file
+import "strings"
+
+// Kind identifies the type of a finding
+type Kind string
+
+const (
+	// KindBrokenLink is reported when the link target note or file does not exist
+	KindBrokenLink Kind = "broken-link"
+	// KindBrokenHeading is reported when the target exists but the #heading does not
+	KindBrokenHeading Kind = "broken-heading"
+	// KindBrokenBlock is reported when the target exists but the #^block-id does not
+	KindBrokenBlock Kind = "broken-block"
+	// KindAmbiguousLink is reported when the link target matches several files
+	KindAmbiguousLink Kind = "ambiguous-link"
+	// KindUnusedSuppression is reported for suppression markers that suppress no finding
+	KindUnusedSuppression Kind = "unused-suppression"
+	// KindOrphanNote is reported for notes without incoming links or embeds
+	KindOrphanNote Kind = "orphan-note"
+	// KindUnusedAttachment is reported for attachments no note or canvas links to or embeds
+	KindUnusedAttachment Kind = "unused-attachment"
+	// KindMalformedFrontmatter is reported for frontmatter that is not valid YAML
+	KindMalformedFrontmatter Kind = "malformed-frontmatter"
+	// KindSchemaViolation is reported for frontmatter not matching the configured schema
+	KindSchemaViolation Kind = "schema-violation"
+)
+
+// Severity controls how a finding is reported
+type Severity string
+
+const (
+	// SeverityError findings fail the run with a non-zero exit code
+	SeverityError Severity = "error"
+	// SeverityWarning findings are reported without failing the run
+	SeverityWarning Severity = "warning"
+	// SeverityOff disables a check
+	SeverityOff Severity = "off"
+)
+
+// LinkFormat is the form of links written by --fix and mv, like Obsidian's
+// "New link format" setting
+type LinkFormat string
+
+const (
+	// LinkFormatShortest links by name, or by vault path if the name is ambiguous
+	LinkFormatShortest LinkFormat = "shortest"
+	// LinkFormatRelative links by path relative to the linking note
+	LinkFormatRelative LinkFormat = "relative"
+	// LinkFormatAbsolute links by path from the vault root
+	LinkFormatAbsolute LinkFormat = "absolute"
+)
+
+// Link represents a wiki link or markdown link found in a markdown file
 type Link struct {
-	Raw     string // "[[Note#Heading|alias]]"
-	Target  string // "Note" (before # or |)
-	Heading string // "Heading" (optional)
-	Alias   string // "alias" (optional)
-	IsEmbed bool   // true if "![[..."
-	Line    int    // line number in file
+	Raw        string // "[[Note#Heading|alias]]" or "[alias](Note.md#Heading)"
+	Target     string // "Note" (before # or |), URL-decoded path for markdown links
+	Heading    string // "Heading" (optional)
+	BlockID    string // "block-id" from "#^block-id" (optional)
+	Alias      string // "alias" (optional), link text for markdown links
+	IsEmbed    bool   // true if "![[..." or "![..."
+	IsMarkdown bool   // true if "[text](path)" instead of "[[...]]"
+	IsHTML     bool   // true for src or href of an HTML tag, a markdown link counted as use only
+	Line       int    // line number in file
+	Source     string // path of the file containing the link
+	Property   string // frontmatter property containing the link, empty in the note body
+
+	Column         int // 1-based rune column of the first character
+	EndColumn      int // 1-based rune column after the last character
+	ColumnUTF16    int // 1-based UTF-16 column of the first character, as used by editors
+	EndColumnUTF16 int // 1-based UTF-16 column after the last character
+	Offset         int // byte offset of the first character in the file
+	EndOffset      int // byte offset after the last character in the file
+}
+
+// WithTarget returns Raw with the link target replaced, keeping the embed prefix,
+// #heading, #^block and |alias. Spaces in markdown link paths are escaped as %20.
+func (l *Link) WithTarget(target string) string {
+	if l.IsHTML {
+		// Raw is the attribute like src="path", the value ends at the closing quote
+		start := strings.IndexAny(l.Raw, `"'`) + 1
+		if start == 0 {
+			return l.Raw
+		}
+		end := strings.IndexAny(l.Raw[start:], "#?"+l.Raw[start-1:start])
+		if end < 0 {
+			end = len(l.Raw) - start
+		}
+		return l.Raw[:start] + strings.ReplaceAll(target, " ", "%20") + l.Raw[start+end:]
+	}
+	if !l.IsMarkdown {
+		prefix := "[["
+		if l.IsEmbed {
+			prefix = "![["
+		}
+		inner := strings.TrimSuffix(strings.TrimPrefix(l.Raw, prefix), "]]")
+		end := strings.IndexAny(inner, "#|")
+		if end < 0 {
+			end = len(inner)
+		}
+		if end > 0 && strings.HasPrefix(inner[end-1:], "\\|") {
+			// Keep the \| escaping the alias separator inside tables
+			end--
+		}
+		return prefix + target + inner[end:] + "]]"
+	}
+
+	// Destination follows "](" of inline links or "]:" of reference definitions, after
+	// the link text which may contain an image
+	skip := 0
+	if i := strings.Index(l.Raw, "["+l.Alias+"]"); i >= 0 {
+		skip = i + 1 + len(l.Alias)
+	}
+	start := strings.Index(l.Raw[skip:], "](")
+	if start < 0 {
+		start = strings.Index(l.Raw[skip:], "]:")
+	}
+	if start < 0 {
+		return l.Raw
+	}
+	start += skip + 2
+	for start < len(l.Raw) && (l.Raw[start] == ' ' || l.Raw[start] == '\t') {
+		start++
+	}
+
+	stop := "#?) \t"
+	if strings.HasPrefix(l.Raw[start:], "<") {
+		start++
+		stop = "#?>"
+	} else {
+		target = strings.ReplaceAll(target, " ", "%20")
+	}
+	end := strings.IndexAny(l.Raw[start:], stop)
+	if end < 0 {
+		end = len(l.Raw) - start
+	}
+	return l.Raw[:start] + target + l.Raw[start+end:]
+}
+
+// Suppression disables findings for a range of lines in a file
+type Suppression struct {
+	Raw       string // "%% obsidian-lint-disable-next-line broken-link %%"
+	Kinds     []Kind // suppressed kinds, empty suppresses all kinds
+	Line      int    // line number of the marker
+	StartLine int    // first suppressed line
+	EndLine   int    // last suppressed line, 0 for end of file
+}
+
+// Suppresses returns true if the suppression covers a finding of kind on line
+func (s Suppression) Suppresses(kind Kind, line int) bool {
+	if line < s.StartLine || (s.EndLine > 0 && line > s.EndLine) {
+		return false
+	}
+	if len(s.Kinds) == 0 {
+		return true
+	}
+	for _, k := range s.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsedFile contains everything parsed from a markdown note, so each note is read once
+type ParsedFile struct {
+	Path         string
+	Links        []*Link
+	Aliases      []string
+	Headings     []string
+	BlockIDs     []string
+	Tags         []string
+	Suppressions []Suppression
+	Frontmatter  *Frontmatter // nil if the note has no frontmatter
+}
+
+// Frontmatter contains the top-level properties of the YAML frontmatter of a note
+type Frontmatter struct {
+	Properties []Property
+	Error      string // YAML error if the frontmatter is malformed
+	ErrorLine  int    // line number of the YAML error
+}
+
+// Property returns the property with name, or false if it does not exist
+func (f *Frontmatter) Property(name string) (Property, bool) {
+	for _, property := range f.Properties {
+		if property.Name == name {
+			return property, true
+		}
+	}
+	return Property{}, false
+}
+
+// PropertyKind is the YAML structure of a property value
+type PropertyKind string
+
+const (
+	// PropertyKindScalar is a single value like text, a number or a date
+	PropertyKindScalar PropertyKind = "scalar"
+	// PropertyKindList is a list of values
+	PropertyKindList PropertyKind = "list"
+	// PropertyKindObject is a nested map
+	PropertyKindObject PropertyKind = "object"
+)
+
+// Property is a top-level frontmatter property
+type Property struct {
+	Name   string
+	Kind   PropertyKind
+	Values []PropertyValue // the scalar value or the scalar list items
+	Line   int             // line number of the key
+}
+
+// PropertyValue is a scalar frontmatter value
+type PropertyValue struct {
+	Value  string // value without YAML quotes
+	Tag    string // YAML type: str, int, float, bool, null or timestamp
+	Line   int    // line number of the value
+	Column int    // 1-based rune column of the value, including quotes
 }
 
 // BrokenLink represents a broken link in output
 type BrokenLink struct {
-	Link string `json:"link"`
-	Line int    `json:"line"`
+	Link           string   `json:"link"`
+	Line           int      `json:"line"` // 0 for findings about the whole file
+	Column         int      `json:"column,omitempty"`
+	EndColumn      int      `json:"endColumn,omitempty"`
+	ColumnUTF16    int      `json:"columnUtf16,omitempty"`
+	EndColumnUTF16 int      `json:"endColumnUtf16,omitempty"`
+	Offset         int      `json:"offset,omitempty"`
+	EndOffset      int      `json:"endOffset,omitempty"`
+	Kind           Kind     `json:"kind"`
+	Severity       Severity `json:"severity,omitempty"`
+	Candidates     []string `json:"candidates,omitempty"`  // vault-relative paths of ambiguous targets
+	Opens          string   `json:"opens,omitempty"`       // vault-relative path the link opens in Obsidian
+	Size           int64    `json:"size,omitempty"`        // file size in bytes of unused attachments
+	Fix            string   `json:"fix,omitempty"`         // replacement for Link if one confident match exists
+	Suggestions    []string `json:"suggestions,omitempty"` // nearest existing link targets of broken links
+	Property       string   `json:"property,omitempty"`    // frontmatter property of the finding
+	Detail         string   `json:"detail,omitempty"`      // description of frontmatter findings
 }
 
 // ValidationResult contains all broken links grouped by file
 type ValidationResult struct {
+	VaultPath   string                  // vault directory passed to Validate
+	Files       []string                // all validated notes
 	BrokenLinks map[string][]BrokenLink // file path -> broken links
+	Severities  map[Kind]Severity       // configured severity of every check
+}
+
+// HasErrors returns true if any finding has error severity
+func (r *ValidationResult) HasErrors() bool {
+	for _, links := range r.BrokenLinks {
+		for _, link := range links {
+			if link.Severity == "" || link.Severity == SeverityError {
+				return true
+			}
+		}
+	}
+	return false
 }
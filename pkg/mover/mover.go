@@ -0,0 +1,296 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mover
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/resolver"
+)
+
+//counterfeiter:generate -o ../../mocks/mover.go --fake-name Mover . Mover
+
+// Mover renames or moves a file and updates all links to it
+type Mover interface {
+	// Plan computes the rewritten content of every note linking to oldPath and of
+	// relative links in the moved note itself
+	Plan(
+		ctx context.Context,
+		idx *index.VaultIndex,
+		oldPath string,
+		newPath string,
+	) ([]fixer.Change, error)
+	// Move moves oldPath to newPath and writes changes, either all or none of them.
+	// A change of oldPath is written to newPath.
+	Move(ctx context.Context, oldPath string, newPath string, changes []fixer.Change) error
+}
+
+// New creates a new Mover writing links by name in the given format
+func New(resolver resolver.Resolver, format model.LinkFormat) Mover {
+	return &mover{
+		resolver: resolver,
+		format:   format,
+	}
+}
+
+type mover struct {
+	resolver resolver.Resolver
+	format   model.LinkFormat
+}
+
+// Plan keeps the form of every link with a path: paths stay vault-relative or
+// relative to the linking note. Links by name are written in the link format, names
+// stay names in the shortest format unless the new name is ambiguous. Links opening
+// the file through an alias are not changed.
+func (m *mover) Plan(
+	ctx context.Context,
+	idx *index.VaultIndex,
+	oldPath string,
+	newPath string,
+) ([]fixer.Change, error) {
+	info, err := os.Stat(oldPath)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "file %s not found", oldPath)
+	}
+	if info.IsDir() {
+		return nil, errors.Errorf(ctx, "%s is a folder, only files can be moved", oldPath)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return nil, errors.Errorf(ctx, "file %s already exists", newPath)
+	}
+	if relPath := idx.RelPath(newPath); relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return nil, errors.Errorf(ctx, "file %s is not inside vault", newPath)
+	}
+
+	var changes []fixer.Change
+	for _, note := range idx.Notes() {
+		// #nosec G304 -- file paths come from the vault index, not user input
+		content, err := os.ReadFile(note.Path)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "read file failed")
+		}
+		original := string(content)
+
+		source := note.Path
+		if source == oldPath {
+			source = newPath
+		}
+		var edits []fixer.Edit
+		for _, link := range note.Links {
+			target, ok := m.retarget(ctx, idx, link, source, oldPath, newPath)
+			if !ok {
+				continue
+			}
+			fixed := link.WithTarget(target)
+			if fixed == link.Raw || link.EndOffset > len(original) ||
+				original[link.Offset:link.EndOffset] != link.Raw {
+				continue
+			}
+			edits = append(edits, fixer.Edit{
+				Line:   link.Line,
+				Offset: link.Offset,
+				Old:    link.Raw,
+				New:    fixed,
+			})
+		}
+		if len(edits) == 0 {
+			continue
+		}
+		changes = append(changes, fixer.Change{
+			File:     note.Path,
+			Original: original,
+			Fixed:    fixer.Replace(original, edits),
+			Edits:    edits,
+		})
+	}
+
+	return changes, nil
+}
+
+// retarget returns the new target of a link from source, or false if the link
+// does not need to change
+func (m *mover) retarget(
+	ctx context.Context,
+	idx *index.VaultIndex,
+	link *model.Link,
+	source string,
+	oldPath string,
+	newPath string,
+) (string, bool) {
+	if link.Target == "" {
+		return "", false
+	}
+	resolved := m.resolver.Resolve(ctx, link, idx)
+	if resolved.Path == "" {
+		return "", false
+	}
+
+	_, relative := idx.Relative(link.Source, link.Target)
+	relative = relative && (link.IsMarkdown || index.IsRelative(link.Target))
+	linksToMoved := resolved.Path == oldPath && idx.LinksTo(link, oldPath)
+	movedRelative := link.Source == oldPath && relative
+	if !linksToMoved && !movedRelative {
+		return "", false
+	}
+
+	dest := resolved.Path
+	if linksToMoved {
+		dest = newPath
+	}
+	return formatTarget(idx, link, source, dest, oldPath, relative, m.format), true
+}
+
+// formatTarget returns the target opening dest from source in the form of the
+// original link, or in the link format if the original link is a name
+func formatTarget(
+	idx *index.VaultIndex,
+	link *model.Link,
+	source string,
+	dest string,
+	oldPath string,
+	relative bool,
+	format model.LinkFormat,
+) string {
+	if link.IsMarkdown {
+		if !relative {
+			return idx.RelPath(dest)
+		}
+		return relativeTo(source, dest)
+	}
+
+	trim := func(target string) string {
+		if strings.HasSuffix(strings.ToLower(link.Target), ".md") {
+			return target
+		}
+		return strings.TrimSuffix(target, ".md")
+	}
+	if !strings.Contains(link.Target, "/") {
+		switch format {
+		case model.LinkFormatRelative:
+			relative = true
+		case model.LinkFormatAbsolute:
+			return trim(idx.RelPath(dest))
+		}
+	}
+	switch {
+	case relative:
+		target := relativeTo(source, dest)
+		if !strings.HasPrefix(target, "../") {
+			target = "./" + target
+		}
+		return trim(target)
+	case strings.HasPrefix(link.Target, "/"):
+		return trim("/" + idx.RelPath(dest))
+	case strings.Contains(link.Target, "/"):
+		return trim(idx.RelPath(dest))
+	}
+
+	// Fall back to the path if another file has the same name
+	name := trim(filepath.Base(dest))
+	for _, candidate := range idx.Candidates(name) {
+		if candidate != oldPath && candidate != dest {
+			return trim(idx.RelPath(dest))
+		}
+	}
+	return name
+}
+
+// relativeTo returns the path of dest relative to the folder of source
+func relativeTo(source string, dest string) string {
+	relPath, err := filepath.Rel(filepath.Dir(source), dest)
+	if err != nil {
+		return dest
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// Move stages all changed files next to their destination first, so a failure
+// leaves the vault untouched. If replacing a file fails afterwards, all files
+// written before are restored.
+func (m *mover) Move(
+	ctx context.Context,
+	oldPath string,
+	newPath string,
+	changes []fixer.Change,
+) error {
+	if err := os.MkdirAll(filepath.Dir(newPath), 0750); err != nil {
+		return errors.Wrap(ctx, err, "create folder failed")
+	}
+
+	staged := make([]stagedFile, 0, len(changes))
+	defer func() {
+		for _, file := range staged {
+			_ = os.Remove(file.tmp)
+		}
+	}()
+	for _, change := range changes {
+		dest := change.File
+		if dest == oldPath {
+			dest = newPath
+		}
+		tmp, err := stage(change, filepath.Dir(dest))
+		if tmp != "" {
+			staged = append(staged, stagedFile{tmp: tmp, dest: dest, original: change.Original})
+		}
+		if err != nil {
+			return errors.Wrapf(ctx, err, "stage %s failed", change.File)
+		}
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return errors.Wrap(ctx, err, "move file failed")
+	}
+	for i, file := range staged {
+		if err := os.Rename(file.tmp, file.dest); err != nil {
+			rollback(staged[:i], oldPath, newPath)
+			return errors.Wrapf(ctx, err, "write %s failed", file.dest)
+		}
+	}
+	return nil
+}
+
+// stagedFile is a changed file written to a temporary file
+type stagedFile struct {
+	tmp      string
+	dest     string
+	original string
+}
+
+// stage writes the fixed content to a temporary file in dir with the
+// permissions of the original file
+func stage(change fixer.Change, dir string) (string, error) {
+	info, err := os.Stat(change.File)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(change.File)+".*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.WriteString(change.Fixed); err != nil {
+		_ = tmp.Close()
+		return tmp.Name(), err
+	}
+	if err := tmp.Close(); err != nil {
+		return tmp.Name(), err
+	}
+	return tmp.Name(), os.Chmod(tmp.Name(), info.Mode().Perm())
+}
+
+// rollback restores the original content of replaced files and moves the file back
+func rollback(replaced []stagedFile, oldPath string, newPath string) {
+	for _, file := range replaced {
+		_ = os.WriteFile(file.dest, []byte(file.original), 0600)
+	}
+	_ = os.Rename(newPath, oldPath)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mover_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/fixer"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/mover"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/resolver"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+)
+
+var _ = Describe("Mover", func() {
+	var (
+		ctx     context.Context
+		m       mover.Mover
+		tempDir string
+		oldPath string
+		newPath string
+		err     error
+		write   func(name string, content string) string
+		read    func(name string) string
+		plan    func() []fixer.Change
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		m = mover.New(resolver.New(config.Default()), model.LinkFormatShortest)
+
+		tempDir, err = os.MkdirTemp("", "mover-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		write = func(name string, content string) string {
+			path := filepath.Join(tempDir, filepath.FromSlash(name))
+			Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+		read = func(name string) string {
+			content, err := os.ReadFile(filepath.Join(tempDir, filepath.FromSlash(name)))
+			Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}
+		plan = func() []fixer.Change {
+			ex := exclude.New(nil, nil)
+			files, err := scanner.New(ex).Scan(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			idx, err := index.New(parser.New(), ex, 0).Build(ctx, tempDir, files)
+			Expect(err).NotTo(HaveOccurred())
+			changes, err := m.Plan(ctx, idx, oldPath, newPath)
+			Expect(err).NotTo(HaveOccurred())
+			return changes
+		}
+
+		oldPath = write("Projects/Plan.md", "---\naliases: [Roadmap]\n---\n# Goals\n")
+		newPath = filepath.Join(tempDir, "Archive", "Old Plan.md")
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("rewrites wiki links keeping heading, alias and embed", func() {
+		write("Index.md", "[[Plan]] and [[Plan#Goals|the goals]] and ![[Plan]]\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fixed).To(Equal(
+			"[[Old Plan]] and [[Old Plan#Goals|the goals]] and ![[Old Plan]]\n",
+		))
+		Expect(changes[0].Edits).To(HaveLen(3))
+	})
+
+	It("keeps path-qualified and absolute forms", func() {
+		write("Index.md", "[[Projects/Plan]] [[/Projects/Plan]] [[Plan.md]]\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fixed).To(Equal(
+			"[[Archive/Old Plan]] [[/Archive/Old Plan]] [[Old Plan.md]]\n",
+		))
+	})
+
+	It("rewrites links in frontmatter properties", func() {
+		write("Index.md", "---\nrelated: \"[[Plan]]\"\n---\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fixed).To(Equal("---\nrelated: \"[[Old Plan]]\"\n---\n"))
+	})
+
+	It("uses the path if the new name is ambiguous", func() {
+		write("Other/Old Plan.md", "")
+		write("Index.md", "[[Plan]]\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fixed).To(Equal("[[Archive/Old Plan]]\n"))
+	})
+
+	DescribeTable("writes links by name in the link format",
+		func(format model.LinkFormat, expected string) {
+			m = mover.New(resolver.New(config.Default()), format)
+			write("Notes/Index.md", "[[Plan#Goals]] [[Projects/Plan]]\n")
+
+			changes := plan()
+			Expect(changes).To(HaveLen(1))
+			Expect(changes[0].Fixed).To(Equal(expected))
+		},
+		Entry("shortest", model.LinkFormatShortest, "[[Old Plan#Goals]] [[Archive/Old Plan]]\n"),
+		Entry("relative", model.LinkFormatRelative,
+			"[[../Archive/Old Plan#Goals]] [[Archive/Old Plan]]\n"),
+		Entry("absolute", model.LinkFormatAbsolute,
+			"[[Archive/Old Plan#Goals]] [[Archive/Old Plan]]\n"),
+	)
+
+	It("rewrites markdown links relative to the linking note", func() {
+		write("Notes/Index.md", "[plan](../Projects/Plan.md)\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Fixed).To(Equal("[plan](../Archive/Old%20Plan.md)\n"))
+	})
+
+	It("keeps links through aliases and links to other notes", func() {
+		write("Index.md", "[[Roadmap]] [[Other]]\n")
+		write("Other.md", "")
+
+		Expect(plan()).To(BeEmpty())
+	})
+
+	It("rewrites relative links in the moved note", func() {
+		write("Projects/Sibling.md", "")
+		write("Projects/Plan.md", "[sibling](Sibling.md) [[Sibling]]\n")
+
+		changes := plan()
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].File).To(Equal(oldPath))
+		Expect(changes[0].Fixed).To(Equal("[sibling](../Projects/Sibling.md) [[Sibling]]\n"))
+	})
+
+	It("returns error if the new file exists", func() {
+		newPath = write("Archive/Old Plan.md", "")
+
+		ex := exclude.New(nil, nil)
+		idx, err := index.New(parser.New(), ex, 0).Build(ctx, tempDir, nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = m.Plan(ctx, idx, oldPath, newPath)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("moves the file and writes all changes", func() {
+		write("Index.md", "[[Plan]]\n")
+		write("Projects/Plan.md", "[[Plan#Goals]]\n# Goals\n")
+
+		changes := plan()
+		Expect(m.Move(ctx, oldPath, newPath, changes)).To(Succeed())
+
+		Expect(oldPath).NotTo(BeAnExistingFile())
+		Expect(read("Archive/Old Plan.md")).To(Equal("[[Old Plan#Goals]]\n# Goals\n"))
+		Expect(read("Index.md")).To(Equal("[[Old Plan]]\n"))
+		entries, err := os.ReadDir(filepath.Join(tempDir, "Archive"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("changes nothing if a change cannot be staged", func() {
+		index := write("Index.md", "[[Plan]]\n")
+		changes := plan()
+		Expect(os.Remove(index)).To(Succeed())
+
+		Expect(m.Move(ctx, oldPath, newPath, changes)).NotTo(Succeed())
+		Expect(oldPath).To(BeAnExistingFile())
+		Expect(newPath).NotTo(BeAnExistingFile())
+	})
+})
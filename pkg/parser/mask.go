@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"strings"
+)
+
+// maskNonProse replaces every byte that is not rendered as regular markdown text
+// with a space. Lines of fenced and indented code blocks as reported by
+// codeBlockLines, inline code spans, HTML comments and Obsidian %% comments are
+// masked. Newlines are kept, so line numbers and byte offsets in the result match
+// the original content.
+func (p *parser) maskNonProse(lines []string, code []bool) string {
+	masked := make([]string, len(lines))
+	for i, line := range lines {
+		if code[i] {
+			line = strings.Repeat(" ", len(line))
+		}
+		masked[i] = line
+	}
+
+	return maskInline(strings.Join(masked, "\n"))
+}
+
+// codeBlockLines reports for each line whether it belongs to a fenced code block
+// (including the fence lines) or an indented code block
+func (p *parser) codeBlockLines(lines []string) []bool {
+	code := make([]bool, len(lines))
+	fence := ""
+	inList := false
+	prevBlank := true
+	prevIndentedCode := false
+
+	for i, line := range lines {
+		if fence != "" {
+			code[i] = true
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		if match := p.fenceRegex.FindStringSubmatch(line); match != nil {
+			code[i] = true
+			fence = match[1]
+			prevBlank, prevIndentedCode = false, false
+			continue
+		}
+
+		blank := strings.TrimSpace(line) == ""
+		indented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+
+		// Indented lines continue a list item instead of starting a code block
+		switch {
+		case blank:
+		case p.listItemRegex.MatchString(line):
+			inList = true
+		case !indented:
+			inList = false
+		}
+
+		if indented && !blank && !inList && (prevBlank || prevIndentedCode) {
+			code[i] = true
+		}
+
+		prevIndentedCode = code[i] || (blank && prevIndentedCode)
+		prevBlank = blank
+	}
+
+	return code
+}
+
+// closesFence returns true if line closes the code block opened by fence. Like in
+// CommonMark the closing fence uses the same character, is at least as long and
+// is followed by nothing but whitespace.
+func closesFence(line string, fence string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return false
+	}
+	run := strings.TrimLeft(trimmed, fence[:1])
+	return len(trimmed)-len(run) >= len(fence) && strings.TrimSpace(run) == ""
+}
+
+// maskInline masks inline code spans, HTML comments and %% comments
+func maskInline(content string) string {
+	masked := []byte(content)
+
+	for i := 0; i < len(content); {
+		var end int
+		switch {
+		case strings.HasPrefix(content[i:], "<!--"):
+			end = closingIndex(content, i+len("<!--"), "-->")
+		case strings.HasPrefix(content[i:], "%%"):
+			end = closingIndex(content, i+len("%%"), "%%")
+		case content[i] == '`':
+			end = inlineCodeEnd(content, i)
+		default:
+			i++
+			continue
+		}
+
+		for j := i; j < end; j++ {
+			if masked[j] != '\n' {
+				masked[j] = ' '
+			}
+		}
+		i = end
+	}
+
+	return string(masked)
+}
+
+// closingIndex returns the index after the closing delimiter, or the end of
+// content if the comment is never closed (Obsidian hides the rest of the note)
+func closingIndex(content string, start int, delimiter string) int {
+	pos := strings.Index(content[start:], delimiter)
+	if pos < 0 {
+		return len(content)
+	}
+	return start + pos + len(delimiter)
+}
+
+// inlineCodeEnd returns the index after an inline code span starting at start.
+// A backtick run without a matching closing run of the same length within the
+// paragraph is literal text and only the run itself is skipped.
+func inlineCodeEnd(content string, start int) int {
+	runEnd := start
+	for runEnd < len(content) && content[runEnd] == '`' {
+		runEnd++
+	}
+	run := content[start:runEnd]
+
+	paragraphEnd := len(content)
+	if pos := strings.Index(content[runEnd:], "\n\n"); pos >= 0 {
+		paragraphEnd = runEnd + pos
+	}
+
+	for i := runEnd; i < paragraphEnd; {
+		pos := strings.Index(content[i:paragraphEnd], run)
+		if pos < 0 {
+			break
+		}
+		closeStart := i + pos
+		closeEnd := closeStart + len(run)
+		// Closing run must have exactly the same length
+		if closeEnd < len(content) && content[closeEnd] == '`' {
+			for closeEnd < len(content) && content[closeEnd] == '`' {
+				closeEnd++
+			}
+			i = closeEnd
+			continue
+		}
+		return closeEnd
+	}
+
+	return runEnd
+}
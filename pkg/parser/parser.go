@@ -6,9 +6,15 @@ package parser
 
 import (
 	"context"
+	"encoding/json"
+	"net/url"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/bborbe/errors"
 	"gopkg.in/yaml.v3"
@@ -18,59 +24,335 @@ import (
 
 //counterfeiter:generate -o ../../mocks/parser.go --fake-name Parser . Parser
 
-// Parser extracts wiki links from markdown files
+// Parser extracts wiki links and markdown links from markdown files
 type Parser interface {
-	ParseFile(ctx context.Context, filePath string) ([]*model.Link, error)
-	ParseAliases(ctx context.Context, content string) ([]string, error)
+	Parse(ctx context.Context, filePath string) (*model.ParsedFile, error)
+	ParseContent(ctx context.Context, filePath string, content string) (*model.ParsedFile, error)
+	ParseCanvas(ctx context.Context, filePath string) ([]*model.Link, error)
 }
 
 // New creates a new Parser
 func New() Parser {
 	return &parser{
-		linkRegex: regexp.MustCompile(`(!?\[\[([^\]]+)\]\])`),
+		linkRegex:          regexp.MustCompile(`(!?\[\[([^\]]+)\]\])`),
+		atxHeadingRegex:    regexp.MustCompile(`^ {0,3}#{1,6}[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`),
+		setextHeadingRegex: regexp.MustCompile(`^ {0,3}(?:=+|-+)[ \t]*$`),
+		fenceRegex:         regexp.MustCompile("^ {0,3}(`{3,}|~{3,})"),
+		blockIDRegex:       regexp.MustCompile(`(?:^|\s)\^([A-Za-z0-9-]+)[ \t]*$`),
+		listItemRegex:      regexp.MustCompile(`^[ \t]*(?:[-*+]|\d+[.)])[ \t]`),
+		blockquoteRegex:    regexp.MustCompile(`^ {0,3}>`),
+		tableRowRegex:      regexp.MustCompile(`^[ \t]*\|`),
+		// The link text may contain an image like in [![alt](image.png)](Note.md)
+		markdownLinkRegex: regexp.MustCompile(
+			`!?\[((?:[^\[\]]|!\[[^\[\]]*\]\([^)]*\))*)\]\((<[^>]*>|[^)\s]+)(?:[ \t]+"[^"]*")?\)`,
+		),
+		// Only a title may follow the destination, otherwise the line is prose
+		linkDefinitionRegex: regexp.MustCompile(
+			`^ {0,3}(\[([^\]^][^\]]*)\]:[ \t]*(<[^>]*>|\S+))` +
+				`(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`,
+		),
+		htmlLinkRegex: regexp.MustCompile(
+			`(?i)<(img|a|audio|video|source|iframe|embed)\b[^>]*?\s((?:src|href)[ \t]*=[ \t]*` +
+				`(?:"([^"]*)"|'([^']*)'))`,
+		),
+		externalURLRegex: regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9+.-]*:|//)`),
+		suppressionRegex: regexp.MustCompile(
+			`%%[ \t]*obsidian-lint-(disable-next-line|disable|enable)\b([^%]*)%%`,
+		),
+		tagRegex:       regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`),
+		yamlErrorRegex: regexp.MustCompile(`^yaml: line (\d+): (.*)$`),
 	}
 }
 
 type parser struct {
-	linkRegex *regexp.Regexp
+	linkRegex           *regexp.Regexp
+	atxHeadingRegex     *regexp.Regexp
+	setextHeadingRegex  *regexp.Regexp
+	fenceRegex          *regexp.Regexp
+	blockIDRegex        *regexp.Regexp
+	listItemRegex       *regexp.Regexp
+	blockquoteRegex     *regexp.Regexp
+	tableRowRegex       *regexp.Regexp
+	markdownLinkRegex   *regexp.Regexp
+	linkDefinitionRegex *regexp.Regexp
+	htmlLinkRegex       *regexp.Regexp
+	externalURLRegex    *regexp.Regexp
+	suppressionRegex    *regexp.Regexp
+	tagRegex            *regexp.Regexp
+	yamlErrorRegex      *regexp.Regexp
 }
 
-// ParseFile extracts all wiki links from a markdown file
-func (p *parser) ParseFile(ctx context.Context, filePath string) ([]*model.Link, error) {
+// Parse reads a markdown note once and extracts its links, aliases, headings,
+// block IDs, tags, suppressions and frontmatter
+func (p *parser) Parse(ctx context.Context, filePath string) (*model.ParsedFile, error) {
 	// #nosec G304 -- filePath comes from scanner.Scan(), not user input
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, errors.Wrap(ctx, err, "read file failed")
 	}
+	return p.ParseContent(ctx, filePath, string(content))
+}
+
+// ParseContent extracts links, aliases, headings, block IDs, tags, suppressions and
+// frontmatter from the content of the note at filePath, e.g. an unsaved editor buffer.
+// The frontmatter is decoded and code and comments are masked once for all of them.
+func (p *parser) ParseContent(
+	ctx context.Context,
+	filePath string,
+	content string,
+) (*model.ParsedFile, error) {
+	frontmatter, keys := p.parseFrontmatter(content)
+	doc := p.newDocument(content)
+	parsed := &model.ParsedFile{
+		Path:        filePath,
+		Links:       append(p.propertyLinks(content, frontmatter), p.parseLinks(doc)...),
+		Aliases:     stringValues(keys.Aliases),
+		Headings:    p.parseHeadings(doc),
+		BlockIDs:    p.parseBlockIDs(doc),
+		Tags:        append(keys.tags(), p.parseTags(doc)...),
+		Frontmatter: frontmatter,
+		Suppressions: append(
+			frontmatterSuppression(frontmatter, keys),
+			p.parseSuppressions(doc)...,
+		),
+	}
+	for _, link := range parsed.Links {
+		link.Source = filePath
+	}
+
+	return parsed, nil
+}
 
-	return p.parseContent(string(content)), nil
+// document is the content of a note prepared once for all extractors
+type document struct {
+	lines  []string // lines with the frontmatter replaced by spaces
+	code   []bool   // lines belonging to code blocks
+	masked []string // lines with code, inline code and comments masked as well
 }
 
-// parseContent extracts links from markdown content
-func (p *parser) parseContent(content string) []*model.Link {
+// newDocument blanks the frontmatter of content and masks everything that is not prose
+func (p *parser) newDocument(content string) *document {
+	lines := strings.Split(maskFrontmatter(content), "\n")
+	code := p.codeBlockLines(lines)
+	return &document{
+		lines:  lines,
+		code:   code,
+		masked: strings.Split(p.maskNonProse(lines, code), "\n"),
+	}
+}
+
+// ParseCanvas extracts the files of file nodes and the links in text nodes of a
+// JSON Canvas file. Malformed canvas files have no links, Obsidian shows them empty.
+func (p *parser) ParseCanvas(ctx context.Context, filePath string) ([]*model.Link, error) {
+	// #nosec G304 -- filePath comes from the vault index, not user input
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read file failed")
+	}
+
+	var canvas struct {
+		Nodes []struct {
+			Type string `json:"type"`
+			File string `json:"file"`
+			Text string `json:"text"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(content, &canvas); err != nil {
+		return nil, nil
+	}
+
 	var links []*model.Link
-	lines := strings.Split(content, "\n")
+	for _, node := range canvas.Nodes {
+		switch node.Type {
+		case "file":
+			if node.File != "" {
+				// File nodes use the full vault-relative path
+				links = append(links, &model.Link{
+					Raw:     node.File,
+					Target:  "/" + node.File,
+					IsEmbed: true,
+				})
+			}
+		case "text":
+			links = append(links, p.parseLinks(p.newDocument(node.Text))...)
+		}
+	}
+	for _, link := range links {
+		link.Source = filePath
+	}
 
-	for lineNum, line := range lines {
-		matches := p.linkRegex.FindAllStringSubmatch(line, -1)
+	return links, nil
+}
+
+// propertyLinks extracts the wiki links in text values of frontmatter properties, like
+// related: "[[Note]]". Links are positioned in content, so they can be fixed in place.
+func (p *parser) propertyLinks(content string, frontmatter *model.Frontmatter) []*model.Link {
+	if frontmatter == nil {
+		return nil
+	}
 
-		for _, match := range matches {
-			if len(match) < 3 {
+	var links []*model.Link
+	lines := strings.Split(content, "\n")
+	lineOffsets := make([]int, len(lines))
+	for i := 1; i < len(lines); i++ {
+		lineOffsets[i] = lineOffsets[i-1] + len(lines[i-1]) + 1
+	}
+	end := len("---\n") + len(extractFrontmatter(content))
+
+	for _, property := range frontmatter.Properties {
+		for _, value := range property.Values {
+			if value.Tag != "str" || value.Line < 1 || value.Line > len(lines) {
 				continue
 			}
+			// Search links from the start of the value, quotes and line breaks of
+			// the YAML source are skipped
+			line := lines[value.Line-1]
+			start := lineOffsets[value.Line-1] + len(firstRunes(line, value.Column-1))
+
+			for _, match := range p.linkRegex.FindAllStringSubmatch(value.Value, -1) {
+				raw, inner := match[1], match[2]
+				pos := strings.Index(content[start:end], raw)
+				if pos < 0 {
+					// Escaped in the YAML source, cannot be addressed
+					continue
+				}
+				offset := start + pos
+				lineNum := strings.Count(content[:offset], "\n") + 1
+				link := p.parseLink(raw, inner, strings.HasPrefix(raw, "!"), lineNum)
+				link.Property = property.Name
+				lineOffset := lineOffsets[lineNum-1]
+				setPosition(linkMatch{
+					start: offset - lineOffset,
+					end:   offset - lineOffset + len(raw),
+					link:  link,
+				}, lines[lineNum-1], lineOffset)
+				links = append(links, link)
+				start = offset + len(raw)
+			}
+		}
+	}
 
-			raw := match[1]   // Full match: ![[Note#Heading|alias]]
-			inner := match[2] // Inner content: Note#Heading|alias
+	return links
+}
+
+// firstRunes returns the first n runes of s
+func firstRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+// parseLinks extracts links from the markdown body, skipping the frontmatter, code
+// and comments
+func (p *parser) parseLinks(doc *document) []*model.Link {
+	var links []*model.Link
+	lineOffset := 0
+
+	for lineNum, line := range doc.masked {
+		var lineLinks []linkMatch
+
+		for _, match := range p.linkRegex.FindAllStringSubmatchIndex(line, -1) {
+			raw := line[match[2]:match[3]]   // Full match: ![[Note#Heading|alias]]
+			inner := line[match[4]:match[5]] // Inner content: Note#Heading|alias
 			isEmbed := strings.HasPrefix(raw, "!")
 
-			link := p.parseLink(raw, inner, isEmbed, lineNum+1)
-			links = append(links, link)
+			lineLinks = append(lineLinks, linkMatch{
+				start: match[0],
+				end:   match[1],
+				link:  p.parseLink(raw, inner, isEmbed, lineNum+1),
+			})
 		}
+
+		lineLinks = append(lineLinks, p.markdownLinks(line, 0, lineNum+1)...)
+
+		// Reference definitions [label]: path.md carry the target of [text][label]
+		if match := p.linkDefinitionRegex.FindStringSubmatchIndex(line); match != nil {
+			raw := line[match[2]:match[3]] // Definition without title: [label]: path.md
+			label := line[match[4]:match[5]]
+			destination := line[match[6]:match[7]]
+
+			link, ok := p.parseMarkdownLink(raw, label, destination, false, lineNum+1)
+			if ok {
+				lineLinks = append(lineLinks, linkMatch{start: match[2], end: match[3], link: link})
+			}
+		}
+
+		// HTML tags like <img src="image.png"> reference files by path
+		for _, match := range p.htmlLinkRegex.FindAllStringSubmatchIndex(line, -1) {
+			raw := line[match[4]:match[5]] // Attribute: src="image.png"
+			destination := ""
+			if match[6] >= 0 {
+				destination = line[match[6]:match[7]]
+			} else {
+				destination = line[match[8]:match[9]]
+			}
+			isEmbed := !strings.EqualFold(line[match[2]:match[3]], "a")
+
+			link, ok := p.parseMarkdownLink(raw, "", destination, isEmbed, lineNum+1)
+			if ok && destination != "" {
+				link.IsHTML = true
+				lineLinks = append(lineLinks, linkMatch{start: match[4], end: match[5], link: link})
+			}
+		}
+
+		sort.SliceStable(lineLinks, func(i, j int) bool {
+			return lineLinks[i].start < lineLinks[j].start
+		})
+		for _, lineLink := range lineLinks {
+			setPosition(lineLink, doc.lines[lineNum], lineOffset)
+			links = append(links, lineLink.link)
+		}
+		lineOffset += len(line) + 1
 	}
 
 	return links
 }
 
+// markdownLinks returns the inline markdown links in s, which starts at byte offset
+// in the line, including images nested in the link text
+func (p *parser) markdownLinks(s string, offset int, lineNum int) []linkMatch {
+	var matches []linkMatch
+	for _, match := range p.markdownLinkRegex.FindAllStringSubmatchIndex(s, -1) {
+		raw := s[match[0]:match[1]]         // Full match: ![text](path.md#heading)
+		text := s[match[2]:match[3]]        // Link text: text
+		destination := s[match[4]:match[5]] // Destination: path.md#heading
+		isEmbed := strings.HasPrefix(raw, "!")
+
+		link, ok := p.parseMarkdownLink(raw, text, destination, isEmbed, lineNum)
+		if ok {
+			matches = append(matches, linkMatch{
+				start: offset + match[0],
+				end:   offset + match[1],
+				link:  link,
+			})
+		}
+		matches = append(matches, p.markdownLinks(text, offset+match[2], lineNum)...)
+	}
+	return matches
+}
+
+// setPosition sets the columns and byte offsets of a link found in line, which
+// starts at lineOffset in the file
+func setPosition(match linkMatch, line string, lineOffset int) {
+	match.link.Column = utf8.RuneCountInString(line[:match.start]) + 1
+	match.link.EndColumn = utf8.RuneCountInString(line[:match.end]) + 1
+	match.link.ColumnUTF16 = len(utf16.Encode([]rune(line[:match.start]))) + 1
+	match.link.EndColumnUTF16 = len(utf16.Encode([]rune(line[:match.end]))) + 1
+	match.link.Offset = lineOffset + match.start
+	match.link.EndOffset = lineOffset + match.end
+}
+
+// linkMatch is a parsed link with its byte offset in the line
+type linkMatch struct {
+	start int
+	end   int
+	link  *model.Link
+}
+
 // parseLink parses a single link into components
 func (p *parser) parseLink(raw, inner string, isEmbed bool, lineNum int) *model.Link {
 	link := &model.Link{
@@ -79,10 +361,12 @@ func (p *parser) parseLink(raw, inner string, isEmbed bool, lineNum int) *model.
 		Line:    lineNum,
 	}
 
-	// Split on | first to separate alias
+	// Split on | first to separate alias. Inside tables the pipe is escaped as \|,
+	// the backslash is not part of the target.
 	parts := strings.SplitN(inner, "|", 2)
 	targetPart := parts[0]
 	if len(parts) > 1 {
+		targetPart = strings.TrimSuffix(targetPart, "\\")
 		link.Alias = parts[1]
 	}
 
@@ -93,47 +377,291 @@ func (p *parser) parseLink(raw, inner string, isEmbed bool, lineNum int) *model.
 		link.Heading = strings.TrimSpace(targetParts[1])
 	}
 
+	splitBlockID(link)
+
 	return link
 }
 
-// ParseAliases extracts aliases from YAML frontmatter
-func (p *parser) ParseAliases(ctx context.Context, content string) ([]string, error) {
-	frontmatter := extractFrontmatter(content)
-	if frontmatter == "" {
-		return nil, nil
+// parseMarkdownLink parses a markdown link destination into components.
+// Returns false for external URLs (http, mailto, obsidian, ...) which are not validated.
+func (p *parser) parseMarkdownLink(
+	raw, text, destination string,
+	isEmbed bool,
+	lineNum int,
+) (*model.Link, bool) {
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+	if p.externalURLRegex.MatchString(destination) {
+		return nil, false
 	}
 
-	var data struct {
-		Aliases interface{} `yaml:"aliases"`
+	link := &model.Link{
+		Raw:        raw,
+		Alias:      text,
+		IsEmbed:    isEmbed,
+		IsMarkdown: true,
+		Line:       lineNum,
 	}
 
-	if err := yaml.Unmarshal([]byte(frontmatter), &data); err != nil {
-		// Silently ignore YAML parsing errors - frontmatter might be malformed
-		return nil, nil
+	// Split on # to separate heading, query strings are not part of the path
+	parts := strings.SplitN(destination, "#", 2)
+	link.Target = unescape(strings.SplitN(parts[0], "?", 2)[0])
+	if len(parts) > 1 {
+		link.Heading = unescape(parts[1])
 	}
 
-	if data.Aliases == nil {
-		return nil, nil
+	splitBlockID(link)
+
+	return link, true
+}
+
+// splitBlockID moves a #^id block reference from the heading into BlockID
+func splitBlockID(link *model.Link) {
+	if strings.HasPrefix(link.Heading, "^") {
+		link.BlockID = link.Heading[1:]
+		link.Heading = ""
+	}
+}
+
+// unescape URL-decodes a link path, keeping it unchanged if it is not valid encoding
+func unescape(value string) string {
+	decoded, err := url.PathUnescape(value)
+	if err != nil {
+		return value
 	}
+	return decoded
+}
 
-	// Handle both string and []interface{} (yaml array)
-	switch v := data.Aliases.(type) {
+// parseHeadings extracts all ATX (# Heading) and setext (underlined) headings
+func (p *parser) parseHeadings(doc *document) []string {
+	var headings []string
+	// A list item or blockquote continues until the next blank line
+	inContainer := false
+	// Structure is detected on masked lines so code blocks and comments are
+	// skipped, while the heading text is taken from the original line
+	for i, line := range doc.lines {
+		if strings.TrimSpace(doc.masked[i]) == "" {
+			inContainer = false
+			continue
+		}
+
+		if p.atxHeadingRegex.MatchString(doc.masked[i]) {
+			match := p.atxHeadingRegex.FindStringSubmatch(line)
+			if match != nil && strings.TrimSpace(match[1]) != "" {
+				headings = append(headings, strings.TrimSpace(match[1]))
+			}
+			continue
+		}
+
+		// Setext heading: paragraph line underlined by === or ---. After list items,
+		// blockquotes, table rows and other underlines --- is a thematic break.
+		if p.listItemRegex.MatchString(doc.masked[i]) ||
+			p.blockquoteRegex.MatchString(doc.masked[i]) {
+			inContainer = true
+		}
+		if inContainer || p.tableRowRegex.MatchString(doc.masked[i]) ||
+			p.setextHeadingRegex.MatchString(doc.masked[i]) {
+			continue
+		}
+		if i+1 < len(doc.lines) && p.setextHeadingRegex.MatchString(doc.masked[i+1]) {
+			headings = append(headings, strings.TrimSpace(line))
+		}
+	}
+
+	return headings
+}
+
+// parseBlockIDs extracts all block identifiers (^block-id at the end of a line)
+func (p *parser) parseBlockIDs(doc *document) []string {
+	var blockIDs []string
+	for _, line := range doc.masked {
+		if match := p.blockIDRegex.FindStringSubmatch(line); match != nil {
+			blockIDs = append(blockIDs, match[1])
+		}
+	}
+	return blockIDs
+}
+
+// parseTags extracts inline #tags without the leading #. Purely numeric tags like
+// #123 are not tags in Obsidian.
+func (p *parser) parseTags(doc *document) []string {
+	var tags []string
+	for _, line := range doc.masked {
+		for _, match := range p.tagRegex.FindAllStringSubmatch(line, -1) {
+			if strings.Trim(match[1], "0123456789") != "" {
+				tags = append(tags, match[1])
+			}
+		}
+	}
+	return tags
+}
+
+// parseTagList splits a comma or space separated tag property value
+func parseTagList(value string) []string {
+	var tags []string
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		tags = append(tags, strings.TrimPrefix(field, "#"))
+	}
+	return tags
+}
+
+// frontmatterKeys are the frontmatter properties used by the parser itself
+type frontmatterKeys struct {
+	Aliases      interface{} `yaml:"aliases"`
+	Tags         interface{} `yaml:"tags"`
+	ObsidianLint struct {
+		Ignore interface{} `yaml:"ignore"`
+	} `yaml:"obsidian-lint"`
+}
+
+// tags returns the tags of the tags property, without the leading #
+func (k frontmatterKeys) tags() []string {
+	var tags []string
+	for _, value := range stringValues(k.Tags) {
+		tags = append(tags, parseTagList(value)...)
+	}
+	return tags
+}
+
+// stringValues returns a string property value, or the strings of a list value
+func stringValues(value interface{}) []string {
+	switch v := value.(type) {
 	case string:
-		return []string{v}, nil
+		return []string{v}
 	case []interface{}:
-		var aliases []string
+		var values []string
 		for _, item := range v {
 			if str, ok := item.(string); ok {
-				aliases = append(aliases, str)
+				values = append(values, str)
 			}
 		}
-		return aliases, nil
+		return values
 	default:
-		return nil, nil
+		return nil
+	}
+}
+
+// parseFrontmatter decodes the YAML frontmatter once and returns its top-level
+// properties and the keys used by the parser, or nil if the note has none. Malformed
+// YAML is returned in Error instead of failing, so it can be reported like any other
+// finding. Malformed frontmatter has no aliases, tags or suppressions.
+func (p *parser) parseFrontmatter(content string) (*model.Frontmatter, frontmatterKeys) {
+	var keys frontmatterKeys
+	frontmatter := extractFrontmatter(content)
+	if frontmatter == "" {
+		return nil, keys
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(frontmatter), &document); err != nil {
+		return p.frontmatterError(err), keys
+	}
+
+	result := &model.Frontmatter{}
+	if len(document.Content) == 0 {
+		// Only comments
+		return result, keys
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		result.Error = "frontmatter is not a map of properties"
+		result.ErrorLine = root.Line + frontmatterLine
+		return result, keys
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if _, exists := result.Property(key.Value); exists {
+			return &model.Frontmatter{
+				Error:     "duplicate property " + key.Value,
+				ErrorLine: key.Line + frontmatterLine,
+			}, keys
+		}
+		result.Properties = append(result.Properties, parseProperty(key, value))
+	}
+
+	// Values of unexpected type are skipped, the other keys are still decoded
+	_ = root.Decode(&keys)
+
+	return result, keys
+}
+
+// frontmatterLine is added to YAML line numbers, the frontmatter starts after the
+// opening --- on line 1
+const frontmatterLine = 1
+
+// frontmatterError converts a YAML error, errors without line refer to the opening ---
+func (p *parser) frontmatterError(err error) *model.Frontmatter {
+	match := p.yamlErrorRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return &model.Frontmatter{Error: strings.TrimPrefix(err.Error(), "yaml: "), ErrorLine: 1}
+	}
+	line, _ := strconv.Atoi(match[1])
+	return &model.Frontmatter{Error: match[2], ErrorLine: line + frontmatterLine}
+}
+
+// parseProperty converts a top-level key and its value node
+func parseProperty(key *yaml.Node, value *yaml.Node) model.Property {
+	property := model.Property{
+		Name: key.Value,
+		Line: key.Line + frontmatterLine,
+	}
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+
+	switch value.Kind {
+	case yaml.SequenceNode:
+		property.Kind = model.PropertyKindList
+		for _, item := range value.Content {
+			if item.Kind == yaml.AliasNode {
+				item = item.Alias
+			}
+			if item.Kind == yaml.ScalarNode {
+				property.Values = append(property.Values, propertyValue(item))
+			}
+		}
+	case yaml.MappingNode:
+		property.Kind = model.PropertyKindObject
+	default:
+		property.Kind = model.PropertyKindScalar
+		property.Values = []model.PropertyValue{propertyValue(value)}
+	}
+
+	return property
+}
+
+// propertyValue converts a scalar node
+func propertyValue(node *yaml.Node) model.PropertyValue {
+	return model.PropertyValue{
+		Value:  node.Value,
+		Tag:    strings.TrimPrefix(node.ShortTag(), "!!"),
+		Line:   node.Line + frontmatterLine,
+		Column: node.Column,
 	}
 }
 
-// extractFrontmatter extracts YAML frontmatter between --- markers
+// maskFrontmatter replaces the frontmatter with spaces, keeping line numbers and
+// byte offsets intact
+func maskFrontmatter(content string) string {
+	frontmatter := extractFrontmatter(content)
+	if frontmatter == "" {
+		return content
+	}
+
+	end := len("---\n\n---") + len(frontmatter)
+	masked := []byte(content)
+	for i := 0; i < end; i++ {
+		if masked[i] != '\n' {
+			masked[i] = ' '
+		}
+	}
+	return string(masked)
+}
+
+// extractFrontmatter extracts YAML frontmatter between --- markers. The closing
+// marker may end the file without a trailing newline.
 func extractFrontmatter(content string) string {
 	if !strings.HasPrefix(content, "---\n") {
 		return ""
@@ -141,9 +669,100 @@ func extractFrontmatter(content string) string {
 
 	// Find second --- delimiter
 	parts := strings.SplitN(content[4:], "\n---\n", 2)
-	if len(parts) < 2 {
-		return ""
+	if len(parts) == 2 {
+		return parts[0]
+	}
+	if frontmatter, found := strings.CutSuffix(content[4:], "\n---"); found {
+		return frontmatter
+	}
+	return ""
+}
+
+// parseSuppressions extracts suppression markers from %% comments outside of code:
+//
+//	%% obsidian-lint-disable-next-line broken-link %%
+//	%% obsidian-lint-disable broken-heading %% ... %% obsidian-lint-enable %%
+func (p *parser) parseSuppressions(doc *document) []model.Suppression {
+	var suppressions []model.Suppression
+	var open []int // indexes of disable ranges without enable yet
+
+	for i, line := range doc.lines {
+		if doc.code[i] {
+			continue
+		}
+		for _, match := range p.suppressionRegex.FindAllStringSubmatch(line, -1) {
+			suppression := model.Suppression{
+				Raw:   match[0],
+				Kinds: parseKinds(match[2]),
+				Line:  i + 1,
+			}
+
+			switch match[1] {
+			case "disable-next-line":
+				suppression.StartLine = i + 2
+				suppression.EndLine = i + 2
+			case "disable":
+				suppression.StartLine = i + 1
+				open = append(open, len(suppressions))
+			case "enable":
+				// Close all open ranges, the enable marker itself suppresses nothing
+				for _, index := range open {
+					suppressions[index].EndLine = i + 1
+				}
+				open = nil
+				continue
+			}
+			suppressions = append(suppressions, suppression)
+		}
+	}
+
+	return suppressions
+}
+
+// frontmatterSuppression returns the suppression of "obsidian-lint: {ignore: [...]}"
+// which suppresses the whole file
+func frontmatterSuppression(
+	frontmatter *model.Frontmatter,
+	keys frontmatterKeys,
+) []model.Suppression {
+	var kinds []model.Kind
+	for _, value := range stringValues(keys.ObsidianLint.Ignore) {
+		kinds = append(kinds, parseKinds(value)...)
+	}
+	if len(kinds) == 0 {
+		return nil
 	}
 
-	return parts[0]
+	// Report the line of the obsidian-lint key
+	line := 1
+	if property, exists := frontmatter.Property("obsidian-lint"); exists {
+		line = property.Line
+	}
+
+	return []model.Suppression{{
+		Raw:       "obsidian-lint: ignore " + joinKinds(kinds),
+		Kinds:     kinds,
+		Line:      line,
+		StartLine: 0, // includes file level findings like orphan notes
+	}}
+}
+
+// parseKinds splits a space or comma separated list of finding kinds
+func parseKinds(value string) []model.Kind {
+	var kinds []model.Kind
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}) {
+		kinds = append(kinds, model.Kind(field))
+	}
+	return kinds
+}
+
+// joinKinds formats finding kinds as comma separated list
+func joinKinds(kinds []model.Kind) string {
+	parts := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		parts = append(parts, string(kind))
+	}
+	return strings.Join(parts, ", ")
 }
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
 )
 
@@ -37,21 +38,89 @@ var _ = Describe("Parser", func() {
 		}
 	})
 
-	Context("ParseFile", func() {
+	Context("Parse", func() {
+		It("parses links, aliases, headings, blocks, tags and suppressions at once", func() {
+			content := `---
+aliases: [Short]
+tags: [project]
+---
+# Title
+%% obsidian-lint-disable-next-line %%
+See [[Other#Part]] here ^block1
+`
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Path).To(Equal(file))
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Other"))
+			Expect(parsed.Links[0].Source).To(Equal(file))
+			Expect(parsed.Links[0].Line).To(Equal(7))
+			Expect(parsed.Aliases).To(Equal([]string{"Short"}))
+			Expect(parsed.Headings).To(Equal([]string{"Title"}))
+			Expect(parsed.BlockIDs).To(Equal([]string{"block1"}))
+			Expect(parsed.Tags).To(ContainElement("project"))
+			Expect(parsed.Suppressions).To(HaveLen(1))
+			Expect(parsed.Suppressions[0].StartLine).To(Equal(7))
+		})
+
+		It("parses links in frontmatter properties with their position", func() {
+			content := `---
+related: "[[Other Note#Part]]"
+up: [[Unquoted]]
+people:
+  - "[[Alice]]"
+  - text with [[Bob|B]] and ![[Diagram]]
+count: 3
+---
+Body [[Body]]
+`
+
+			parsed, err := p.ParseContent(ctx, "/vault/test.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			var raws, properties []string
+			var lines []int
+			for _, link := range parsed.Links {
+				raws = append(raws, link.Raw)
+				properties = append(properties, link.Property)
+				lines = append(lines, link.Line)
+				Expect(content[link.Offset:link.EndOffset]).To(Equal(link.Raw))
+				Expect(link.Source).To(Equal("/vault/test.md"))
+			}
+			Expect(raws).To(Equal([]string{
+				"[[Other Note#Part]]", "[[Alice]]", "[[Bob|B]]", "![[Diagram]]", "[[Body]]",
+			}))
+			Expect(properties).To(Equal([]string{"related", "people", "people", "people", ""}))
+			Expect(lines).To(Equal([]int{2, 5, 6, 6, 9}))
+			Expect(parsed.Links[0].Target).To(Equal("Other Note"))
+			Expect(parsed.Links[0].Heading).To(Equal("Part"))
+			Expect(parsed.Links[0].Column).To(Equal(11))
+			Expect(parsed.Links[3].IsEmbed).To(BeTrue())
+		})
+
+		It("returns error for missing file", func() {
+			_, err := p.Parse(ctx, filepath.Join(tempDir, "missing.md"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("links", func() {
 		It("extracts basic wiki link", func() {
 			content := "This is a link to [[Note]]."
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("[[Note]]"))
-			Expect(links[0].Target).To(Equal("Note"))
-			Expect(links[0].Heading).To(BeEmpty())
-			Expect(links[0].Alias).To(BeEmpty())
-			Expect(links[0].IsEmbed).To(BeFalse())
-			Expect(links[0].Line).To(Equal(1))
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[[Note]]"))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Heading).To(BeEmpty())
+			Expect(parsed.Links[0].Alias).To(BeEmpty())
+			Expect(parsed.Links[0].IsEmbed).To(BeFalse())
+			Expect(parsed.Links[0].Line).To(Equal(1))
 		})
 
 		It("extracts link with alias", func() {
@@ -59,13 +128,13 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("[[Note|Display Text]]"))
-			Expect(links[0].Target).To(Equal("Note"))
-			Expect(links[0].Alias).To(Equal("Display Text"))
-			Expect(links[0].IsEmbed).To(BeFalse())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[[Note|Display Text]]"))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Alias).To(Equal("Display Text"))
+			Expect(parsed.Links[0].IsEmbed).To(BeFalse())
 		})
 
 		It("extracts link with heading", func() {
@@ -73,13 +142,13 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("[[Note#Heading]]"))
-			Expect(links[0].Target).To(Equal("Note"))
-			Expect(links[0].Heading).To(Equal("Heading"))
-			Expect(links[0].Alias).To(BeEmpty())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[[Note#Heading]]"))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Heading).To(Equal("Heading"))
+			Expect(parsed.Links[0].Alias).To(BeEmpty())
 		})
 
 		It("extracts link with heading and alias", func() {
@@ -87,13 +156,27 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[[Note#Heading|Display]]"))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Heading).To(Equal("Heading"))
+			Expect(parsed.Links[0].Alias).To(Equal("Display"))
+		})
+
+		It("extracts block reference", func() {
+			content := "Embed: ![[Note#^abc-123]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("[[Note#Heading|Display]]"))
-			Expect(links[0].Target).To(Equal("Note"))
-			Expect(links[0].Heading).To(Equal("Heading"))
-			Expect(links[0].Alias).To(Equal("Display"))
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Heading).To(BeEmpty())
+			Expect(parsed.Links[0].BlockID).To(Equal("abc-123"))
+			Expect(parsed.Links[0].IsEmbed).To(BeTrue())
 		})
 
 		It("extracts embed link", func() {
@@ -101,12 +184,12 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("![[Note]]"))
-			Expect(links[0].Target).To(Equal("Note"))
-			Expect(links[0].IsEmbed).To(BeTrue())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("![[Note]]"))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].IsEmbed).To(BeTrue())
 		})
 
 		It("extracts embed with file extension", func() {
@@ -114,12 +197,12 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Raw).To(Equal("![[image.png]]"))
-			Expect(links[0].Target).To(Equal("image.png"))
-			Expect(links[0].IsEmbed).To(BeTrue())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("![[image.png]]"))
+			Expect(parsed.Links[0].Target).To(Equal("image.png"))
+			Expect(parsed.Links[0].IsEmbed).To(BeTrue())
 		})
 
 		It("extracts multiple links from same line", func() {
@@ -127,13 +210,13 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(2))
-			Expect(links[0].Target).To(Equal("Note1"))
-			Expect(links[1].Target).To(Equal("Note2"))
-			Expect(links[0].Line).To(Equal(1))
-			Expect(links[1].Line).To(Equal(1))
+			Expect(parsed.Links).To(HaveLen(2))
+			Expect(parsed.Links[0].Target).To(Equal("Note1"))
+			Expect(parsed.Links[1].Target).To(Equal("Note2"))
+			Expect(parsed.Links[0].Line).To(Equal(1))
+			Expect(parsed.Links[1].Line).To(Equal(1))
 		})
 
 		It("tracks line numbers correctly", func() {
@@ -141,11 +224,11 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(2))
-			Expect(links[0].Line).To(Equal(1))
-			Expect(links[1].Line).To(Equal(3))
+			Expect(parsed.Links).To(HaveLen(2))
+			Expect(parsed.Links[0].Line).To(Equal(1))
+			Expect(parsed.Links[1].Line).To(Equal(3))
 		})
 
 		It("handles folder paths in links", func() {
@@ -153,10 +236,266 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("folder/Note"))
+		})
+
+		It("records the source file on each link", func() {
+			content := "Link: [[Note]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Source).To(Equal(file))
+		})
+
+		It("ignores links in fenced code blocks", func() {
+			content := "```markdown\n[[InFence]]\n```\n~~~\n[[InTilde]]\n~~~\n[[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+			Expect(parsed.Links[0].Line).To(Equal(7))
+		})
+
+		It("closes fenced code blocks only on a fence of the same character and length", func() {
+			content := "````\n```\n[[InFence]]\n~~~~\n``` text\n````\n" +
+				"~~~\n[[InTilde]]\n```\n~~~~\n[[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+			Expect(parsed.Links[0].Line).To(Equal(11))
+		})
+
+		It("strips the escaped pipe of links in table cells from the target", func() {
+			content := "| Note | Link |\n| --- | --- |\n| a | [[Note#Part\\|alias]] [[Other\\|x]] |"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(2))
+			Expect(parsed.Links[0].Target).To(Equal("Note"))
+			Expect(parsed.Links[0].Heading).To(Equal("Part"))
+			Expect(parsed.Links[0].Alias).To(Equal("alias"))
+			Expect(parsed.Links[1].Target).To(Equal("Other"))
+			Expect(parsed.Links[1].WithTarget("New")).To(Equal("[[New\\|x]]"))
+		})
+
+		It("ignores links in indented code blocks", func() {
+			content := "Text\n\n    [[InCode]]\n\n[[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+		})
+
+		It("keeps links in indented list items", func() {
+			content := "- item\n\n    [[Nested]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Nested"))
+		})
+
+		It("ignores links in inline code", func() {
+			content := "Use `[[Example]]` or ``[[Other]] with ` tick`` but [[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+		})
+
+		It("treats unmatched backtick as literal text", func() {
+			content := "A ` tick and [[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+		})
+
+		It("ignores links in HTML comments", func() {
+			content := "<!-- [[Hidden]]\n[[AlsoHidden]] -->\n[[Real]]"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+			Expect(parsed.Links[0].Line).To(Equal(3))
+		})
+
+		It("ignores links in Obsidian comments", func() {
+			content := "%% [[Hidden]] %% [[Real]]\n%%\n[[Block]]\n%%"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Real"))
+		})
+
+		It("extracts inline markdown link", func() {
+			content := "See [the plan](Projects/Some%20Note.md#Next%20Steps) here"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).
+				To(Equal("[the plan](Projects/Some%20Note.md#Next%20Steps)"))
+			Expect(parsed.Links[0].Target).To(Equal("Projects/Some Note.md"))
+			Expect(parsed.Links[0].Heading).To(Equal("Next Steps"))
+			Expect(parsed.Links[0].Alias).To(Equal("the plan"))
+			Expect(parsed.Links[0].IsMarkdown).To(BeTrue())
+			Expect(parsed.Links[0].IsEmbed).To(BeFalse())
+		})
+
+		It("extracts markdown embed with angle brackets and title", func() {
+			content := `![diagram](<assets/My Image.png> "Title")`
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("assets/My Image.png"))
+			Expect(parsed.Links[0].IsEmbed).To(BeTrue())
+			Expect(parsed.Links[0].IsMarkdown).To(BeTrue())
+		})
+
+		It("extracts reference-style link definitions", func() {
+			content := "See [the plan][plan].\n\n[plan]: ../Plan.md#^block-1\n[^1]: A footnote"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[plan]: ../Plan.md#^block-1"))
+			Expect(parsed.Links[0].Target).To(Equal("../Plan.md"))
+			Expect(parsed.Links[0].BlockID).To(Equal("block-1"))
+			Expect(parsed.Links[0].Line).To(Equal(3))
+		})
+
+		It("extracts reference-style link definitions with a title", func() {
+			content := "[plan]: <Plan.md> \"The plan\"\n[TODO]: call Bob tomorrow"
+
+			parsed, err := p.ParseContent(ctx, "/vault/test.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Raw).To(Equal("[plan]: <Plan.md>"))
+			Expect(parsed.Links[0].Target).To(Equal("Plan.md"))
+		})
+
+		It("extracts a link whose text is an image", func() {
+			content := "Go [![logo](img/a.png)](Note.md) now"
+
+			parsed, err := p.ParseContent(ctx, "/vault/test.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(2))
+			Expect(parsed.Links[0].Target).To(Equal("Note.md"))
+			Expect(parsed.Links[0].IsEmbed).To(BeFalse())
+			Expect(parsed.Links[0].Column).To(Equal(4))
+			Expect(parsed.Links[0].WithTarget("Other.md")).
+				To(Equal("[![logo](img/a.png)](Other.md)"))
+			Expect(parsed.Links[1].Target).To(Equal("img/a.png"))
+			Expect(parsed.Links[1].IsEmbed).To(BeTrue())
+			Expect(parsed.Links[1].Column).To(Equal(5))
+		})
+
+		It("extracts src and href of HTML tags", func() {
+			content := `<img width="50" src="assets/My%20Image.png"> <a href='Guide.pdf#page=2'>x</a>` +
+				"\n<img src=\"https://example.com/x.png\"> `<img src=\"code.png\">`"
+
+			parsed, err := p.ParseContent(ctx, "/vault/test.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(2))
+			Expect(parsed.Links[0].Raw).To(Equal(`src="assets/My%20Image.png"`))
+			Expect(parsed.Links[0].Target).To(Equal("assets/My Image.png"))
+			Expect(parsed.Links[0].IsHTML).To(BeTrue())
+			Expect(parsed.Links[0].IsEmbed).To(BeTrue())
+			Expect(parsed.Links[0].WithTarget("img/New Image.png")).
+				To(Equal(`src="img/New%20Image.png"`))
+			Expect(parsed.Links[1].Raw).To(Equal(`href='Guide.pdf#page=2'`))
+			Expect(parsed.Links[1].Target).To(Equal("Guide.pdf"))
+			Expect(parsed.Links[1].IsEmbed).To(BeFalse())
+			Expect(parsed.Links[1].WithTarget("Docs/Guide.pdf")).
+				To(Equal(`href='Docs/Guide.pdf#page=2'`))
+		})
+
+		It("skips external markdown links", func() {
+			content := "[a](https://example.com) [b](http://x.y) [c](mailto:me@x.y) " +
+				"[d](obsidian://open?vault=x) [e](//cdn.example.com/x.png)"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(BeEmpty())
+		})
+
+		It("orders wiki and markdown links by position", func() {
+			content := "[a](A.md) [[B]] [c](C.md)"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Links).To(HaveLen(3))
+			Expect(parsed.Links[0].Target).To(Equal("A.md"))
+			Expect(parsed.Links[1].Target).To(Equal("B"))
+			Expect(parsed.Links[2].Target).To(Equal("C.md"))
+		})
+
+		It("records columns and byte offsets", func() {
+			content := "First line\nÄ 😀 [[B]] and [c](C.md)\n  [ref]: D.md"
+			file := filepath.Join(tempDir, "test.md")
+			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
+
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(HaveLen(1))
-			Expect(links[0].Target).To(Equal("folder/Note"))
+			Expect(parsed.Links).To(HaveLen(3))
+
+			// Ä is 2 bytes and 1 UTF-16 unit, 😀 is 4 bytes and 2 UTF-16 units
+			Expect(parsed.Links[0].Column).To(Equal(5))
+			Expect(parsed.Links[0].EndColumn).To(Equal(10))
+			Expect(parsed.Links[0].ColumnUTF16).To(Equal(6))
+			Expect(parsed.Links[0].EndColumnUTF16).To(Equal(11))
+			Expect(parsed.Links[0].Offset).To(Equal(19))
+			Expect(parsed.Links[0].EndOffset).To(Equal(24))
+			Expect(content[parsed.Links[0].Offset:parsed.Links[0].EndOffset]).To(Equal("[[B]]"))
+
+			Expect(parsed.Links[1].Column).To(Equal(15))
+			Expect(content[parsed.Links[1].Offset:parsed.Links[1].EndOffset]).To(Equal("[c](C.md)"))
+
+			Expect(parsed.Links[2].Column).To(Equal(3))
+			Expect(content[parsed.Links[2].Offset:parsed.Links[2].EndOffset]).
+				To(Equal("[ref]: D.md"))
 		})
 
 		It("returns empty slice when no links exist", func() {
@@ -164,22 +503,22 @@ var _ = Describe("Parser", func() {
 			file := filepath.Join(tempDir, "test.md")
 			Expect(os.WriteFile(file, []byte(content), 0600)).To(Succeed())
 
-			links, err := p.ParseFile(ctx, file)
+			parsed, err := p.Parse(ctx, file)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(links).To(BeEmpty())
+			Expect(parsed.Links).To(BeEmpty())
 		})
 	})
 
-	Context("ParseAliases", func() {
+	Context("aliases", func() {
 		It("extracts single alias from frontmatter", func() {
 			content := `---
 aliases: MyAlias
 ---
 Content here`
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(Equal([]string{"MyAlias"}))
+			Expect(parsed.Aliases).To(Equal([]string{"MyAlias"}))
 		})
 
 		It("extracts array of aliases from frontmatter", func() {
@@ -188,9 +527,9 @@ aliases: [AI, Artificial Intelligence, ML]
 ---
 Content here`
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(ConsistOf("AI", "Artificial Intelligence", "ML"))
+			Expect(parsed.Aliases).To(ConsistOf("AI", "Artificial Intelligence", "ML"))
 		})
 
 		It("extracts multiline aliases from frontmatter", func() {
@@ -202,9 +541,9 @@ aliases:
 ---
 Content here`
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(ConsistOf("First Alias", "Second Alias", "Third Alias"))
+			Expect(parsed.Aliases).To(ConsistOf("First Alias", "Second Alias", "Third Alias"))
 		})
 
 		It("returns nil when no aliases field exists", func() {
@@ -213,17 +552,17 @@ title: My Note
 ---
 Content here`
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(BeNil())
+			Expect(parsed.Aliases).To(BeNil())
 		})
 
 		It("returns nil when no frontmatter exists", func() {
 			content := "Just content, no frontmatter"
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(BeNil())
+			Expect(parsed.Aliases).To(BeNil())
 		})
 
 		It("returns nil when frontmatter is incomplete", func() {
@@ -231,9 +570,311 @@ Content here`
 aliases: [AI, ML]
 Content without closing ---`
 
-			aliases, err := p.ParseAliases(ctx, content)
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Aliases).To(BeNil())
+		})
+		It("decodes aliases and tags when other keys have unexpected types", func() {
+			content := "---\naliases: [AI]\ntags: project\nobsidian-lint: off\n---\n"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Aliases).To(Equal([]string{"AI"}))
+			Expect(parsed.Tags).To(Equal([]string{"project"}))
+			Expect(parsed.Suppressions).To(BeEmpty())
+		})
+	})
+
+	Context("headings", func() {
+		It("extracts ATX headings", func() {
+			content := "# Title\n\nText\n## Sub Heading ##\n###### Deep"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Title", "Sub Heading", "Deep"}))
+		})
+
+		It("extracts setext headings", func() {
+			content := "Title\n=====\n\nSection\n---\nText"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Title", "Section"}))
+		})
+
+		It("extracts setext headings only from paragraph lines", func() {
+			content := "- item\n---\n\n> quote\ncontinued\n---\n\n| a | b |\n---\n\n---\n---\n\n" +
+				"# ATX\n---\n\nText\n---"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"ATX", "Text"}))
+		})
+
+		It("ignores tags and hashes without space", func() {
+			content := "#tag\n#NotAHeading"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(BeEmpty())
+		})
+
+		It("ignores frontmatter delimiters", func() {
+			content := "---\ntitle: x\n---\n# Heading"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Heading"}))
+		})
+
+		It("ignores headings in comments", func() {
+			content := "%%\n# Hidden\n%%\n<!--\n# Also Hidden\n-->\n# Real"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Real"}))
+		})
+
+		It("keeps inline code in heading text", func() {
+			content := "# Using `[[links]]`"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Using `[[links]]`"}))
+		})
+
+		It("ignores headings in fenced code blocks", func() {
+			content := "```bash\n# comment\n```\n# Real"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Headings).To(Equal([]string{"Real"}))
+		})
+	})
+
+	Context("block IDs", func() {
+		It("extracts block IDs at end of lines", func() {
+			content := "A paragraph. ^abc123\n- item ^item-1\n\n| a | b |\n\n^table-id"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.BlockIDs).To(Equal([]string{"abc123", "item-1", "table-id"}))
+		})
+
+		It("ignores carets not at end of line", func() {
+			content := "2^10 is 1024\nword^notblock\nx ^mid text"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.BlockIDs).To(BeEmpty())
+		})
+
+		It("ignores block IDs in fenced code blocks", func() {
+			content := "```\ncode ^fake\n```\ntext ^real"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.BlockIDs).To(Equal([]string{"real"}))
+		})
+	})
+
+	Context("suppressions", func() {
+		It("parses disable-next-line with kinds", func() {
+			content := "Text\n%% obsidian-lint-disable-next-line broken-link, broken-heading %%\n[[Later]]"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Suppressions).To(HaveLen(1))
+			Expect(parsed.Suppressions[0].Line).To(Equal(2))
+			Expect(parsed.Suppressions[0].Kinds).
+				To(Equal([]model.Kind{model.KindBrokenLink, model.KindBrokenHeading}))
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 3)).To(BeTrue())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 4)).To(BeFalse())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenBlock, 3)).To(BeFalse())
+		})
+
+		It("parses disable and enable ranges", func() {
+			content := "%% obsidian-lint-disable %%\n[[A]]\n%% obsidian-lint-enable %%\n[[B]]"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Suppressions).To(HaveLen(1))
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 2)).To(BeTrue())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindAmbiguousLink, 2)).To(BeTrue())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 4)).To(BeFalse())
+		})
+
+		It("keeps unclosed disable open until end of file", func() {
+			content := "[[A]]\n%% obsidian-lint-disable broken-link %%\n\n\n[[B]]"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Suppressions).To(HaveLen(1))
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 1)).To(BeFalse())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 5)).To(BeTrue())
+		})
+
+		It("parses frontmatter ignore list", func() {
+			content := "---\nobsidian-lint:\n  ignore: [broken-link]\n---\n[[A]]"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(aliases).To(BeNil())
+			Expect(parsed.Suppressions).To(HaveLen(1))
+			Expect(parsed.Suppressions[0].Line).To(Equal(2))
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenLink, 5)).To(BeTrue())
+			Expect(parsed.Suppressions[0].Suppresses(model.KindBrokenHeading, 5)).To(BeFalse())
+		})
+
+		It("ignores markers in code blocks", func() {
+			content := "```\n%% obsidian-lint-disable %%\n```\n[[A]]"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Suppressions).To(BeEmpty())
+		})
+	})
+
+	Context("tags", func() {
+		It("extracts frontmatter and inline tags", func() {
+			content := "---\ntags: [root, \"#project\"]\n---\nText #inbox and #area/work."
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Tags).To(Equal([]string{"root", "project", "inbox", "area/work"}))
+		})
+
+		It("ignores headings, numbers, anchors and code", func() {
+			content := "# Heading\nIssue #123 see [[Note#Part]]\n`#code`\n```\n#fenced\n```"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Tags).To(BeEmpty())
+		})
+	})
+
+	Context("frontmatter", func() {
+		It("returns nil without frontmatter", func() {
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", "# Note\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter).To(BeNil())
+		})
+
+		It("extracts frontmatter closed at the end of the file", func() {
+			content := "---\naliases: [Goal]\nrelated: \"[[Other]]\"\n---"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter).NotTo(BeNil())
+			Expect(parsed.Aliases).To(Equal([]string{"Goal"}))
+			Expect(parsed.Headings).To(BeEmpty())
+			Expect(parsed.Links).To(HaveLen(1))
+			Expect(parsed.Links[0].Target).To(Equal("Other"))
+			Expect(parsed.Links[0].Property).To(Equal("related"))
+		})
+
+		It("extracts properties with types and lines", func() {
+			content := "---\ntitle: \"Plan\"\ndue: 2024-01-31\ndone: false\n" +
+				"tags:\n  - project\n  - 42\nmeta: {a: 1}\nowner:\n---\n"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter.Error).To(BeEmpty())
+			Expect(parsed.Frontmatter.Properties).To(Equal([]model.Property{
+				{
+					Name:   "title",
+					Kind:   model.PropertyKindScalar,
+					Values: []model.PropertyValue{{Value: "Plan", Tag: "str", Line: 2, Column: 8}},
+					Line:   2,
+				},
+				{
+					Name: "due",
+					Kind: model.PropertyKindScalar,
+					Values: []model.PropertyValue{
+						{Value: "2024-01-31", Tag: "timestamp", Line: 3, Column: 6},
+					},
+					Line: 3,
+				},
+				{
+					Name: "done",
+					Kind: model.PropertyKindScalar,
+					Values: []model.PropertyValue{
+						{Value: "false", Tag: "bool", Line: 4, Column: 7},
+					},
+					Line: 4,
+				},
+				{
+					Name: "tags",
+					Kind: model.PropertyKindList,
+					Values: []model.PropertyValue{
+						{Value: "project", Tag: "str", Line: 6, Column: 5},
+						{Value: "42", Tag: "int", Line: 7, Column: 5},
+					},
+					Line: 5,
+				},
+				{Name: "meta", Kind: model.PropertyKindObject, Line: 8},
+				{
+					Name:   "owner",
+					Kind:   model.PropertyKindScalar,
+					Values: []model.PropertyValue{{Value: "", Tag: "null", Line: 9, Column: 7}},
+					Line:   9,
+				},
+			}))
+		})
+
+		It("returns malformed YAML as error with file line", func() {
+			content := "---\ntitle: Plan\nstatus: open: yes\n---\n"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter.Properties).To(BeEmpty())
+			Expect(parsed.Frontmatter.Error).
+				To(Equal("mapping values are not allowed in this context"))
+			Expect(parsed.Frontmatter.ErrorLine).To(Equal(3))
+		})
+
+		It("returns duplicate properties as error", func() {
+			content := "---\ntitle: A\ntitle: B\n---\n"
+
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter.Error).To(Equal("duplicate property title"))
+			Expect(parsed.Frontmatter.ErrorLine).To(Equal(3))
+		})
+
+		It("returns frontmatter that is not a map as error", func() {
+			parsed, err := p.ParseContent(ctx, "/vault/Note.md", "---\n- a\n- b\n---\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Frontmatter.Error).To(Equal("frontmatter is not a map of properties"))
+			Expect(parsed.Frontmatter.ErrorLine).To(Equal(2))
+		})
+	})
+
+	Context("ParseCanvas", func() {
+		It("extracts file nodes and links in text nodes", func() {
+			canvas := filepath.Join(tempDir, "Board.canvas")
+			content := `{"nodes":[
+				{"id":"1","type":"file","file":"Attachments/image.png"},
+				{"id":"2","type":"text","text":"See [[Note]]"},
+				{"id":"3","type":"link","url":"https://example.com"}
+			]}`
+			Expect(os.WriteFile(canvas, []byte(content), 0600)).To(Succeed())
+
+			links, err := p.ParseCanvas(ctx, canvas)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(links).To(HaveLen(2))
+			Expect(links[0].Target).To(Equal("/Attachments/image.png"))
+			Expect(links[0].Source).To(Equal(canvas))
+			Expect(links[1].Target).To(Equal("Note"))
+			Expect(links[1].Source).To(Equal(canvas))
+		})
+
+		It("returns no links for malformed canvas", func() {
+			canvas := filepath.Join(tempDir, "Broken.canvas")
+			Expect(os.WriteFile(canvas, []byte("{"), 0600)).To(Succeed())
+
+			links, err := p.ParseCanvas(ctx, canvas)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(links).To(BeEmpty())
 		})
 	})
 })
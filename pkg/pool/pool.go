@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Run calls fn for every index from 0 to n-1 on at most workers goroutines.
+// Workers below 1 use one goroutine per CPU. The first error cancels the context
+// passed to fn and stops scheduling further indexes. Callers store results by
+// index, so the merged result does not depend on scheduling.
+func Run(ctx context.Context, workers int, n int, fn func(ctx context.Context, i int) error) error {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, n)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	indexes := make(chan int)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(ctx, i); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+schedule:
+	for i := 0; i < n; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break schedule
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
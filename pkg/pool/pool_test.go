@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/pool"
+)
+
+var _ = Describe("Run", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("calls fn for every index", func() {
+		results := make([]int, 100)
+		err := pool.Run(ctx, 4, len(results), func(ctx context.Context, i int) error {
+			results[i] = i * 2
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for i, result := range results {
+			Expect(result).To(Equal(i * 2))
+		}
+	})
+
+	It("uses one worker per CPU if workers is 0", func() {
+		var calls atomic.Int32
+		err := pool.Run(ctx, 0, 10, func(ctx context.Context, i int) error {
+			calls.Add(1)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int32(10)))
+	})
+
+	It("does nothing without work", func() {
+		err := pool.Run(ctx, 4, 0, func(ctx context.Context, i int) error {
+			Fail("fn must not be called")
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the first error and stops scheduling", func() {
+		var calls atomic.Int32
+		err := pool.Run(ctx, 1, 100, func(ctx context.Context, i int) error {
+			calls.Add(1)
+			if i == 2 {
+				return errors.New("banana")
+			}
+			return nil
+		})
+		Expect(err).To(MatchError("banana"))
+		Expect(calls.Load()).To(BeNumerically("<", 100))
+	})
+
+	It("returns the context error if ctx is canceled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		var calls atomic.Int32
+		err := pool.Run(ctx, 2, 100, func(ctx context.Context, i int) error {
+			calls.Add(1)
+			return nil
+		})
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(calls.Load()).To(BeNumerically("<", 100))
+	})
+})
@@ -6,28 +6,123 @@ package resolver
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/bborbe/obsidian-lint/pkg/config"
 	"github.com/bborbe/obsidian-lint/pkg/index"
 	"github.com/bborbe/obsidian-lint/pkg/model"
 )
 
 //counterfeiter:generate -o ../../mocks/resolver.go --fake-name Resolver . Resolver
 
-// Resolver resolves wiki links against a vault index
+// Resolver resolves wiki links and markdown links against a vault index
 type Resolver interface {
-	Resolve(ctx context.Context, link *model.Link, index *index.VaultIndex) bool
+	Resolve(ctx context.Context, link *model.Link, index *index.VaultIndex) Result
 }
 
-// New creates a new Resolver
-func New() Resolver {
-	return &resolver{}
+// Result describes the outcome of resolving a link
+type Result struct {
+	Kind       model.Kind // empty if the link resolves without problems
+	Path       string     // file the link opens, empty if unknown
+	Candidates []string   // all matching files if the target is ambiguous
 }
 
-type resolver struct{}
+// New creates a new Resolver. With cfg.PreferClosest ambiguous targets are resolved
+// like Obsidian does, preferring the linking file's folder and then the shortest path.
+func New(cfg *config.Config) Resolver {
+	return &resolver{
+		preferClosest: cfg.PreferClosest,
+	}
+}
+
+type resolver struct {
+	preferClosest bool
+}
+
+// Resolve checks if a link target and its heading or block exist in the vault index
+func (r *resolver) Resolve(
+	ctx context.Context,
+	link *model.Link,
+	index *index.VaultIndex,
+) Result {
+	// [[#Heading]] and [[#^block]] point into the file containing the link
+	candidates := []string{link.Source}
+	if link.Target != "" || (link.Heading == "" && link.BlockID == "") {
+		candidates = r.candidates(link, index)
+		if len(candidates) == 0 {
+			return Result{Kind: model.KindBrokenLink}
+		}
+	}
+
+	// Anchors are checked in the file the link opens
+	path := candidates[0]
+	if kind := r.resolveAnchor(link, index, path); kind != "" {
+		return Result{Kind: kind, Path: path}
+	}
+
+	if len(candidates) > 1 {
+		result := Result{Kind: model.KindAmbiguousLink, Candidates: candidates}
+		if r.preferClosest {
+			result.Path = path
+		}
+		return result
+	}
+
+	return Result{Path: path}
+}
+
+// candidates returns all files the link target matches, the file the link opens first
+func (r *resolver) candidates(link *model.Link, idx *index.VaultIndex) []string {
+	// Markdown links and ./ or ../ wiki links are paths relative to the linking file
+	if link.IsMarkdown || index.IsRelative(link.Target) {
+		if path, exists := idx.Relative(link.Source, link.Target); exists {
+			return []string{path}
+		}
+	}
+	if index.IsRelative(link.Target) {
+		return nil
+	}
+
+	candidates := idx.Candidates(link.Target)
+	if r.preferClosest && len(candidates) > 1 {
+		sourceDir := filepath.Dir(link.Source)
+		sort.SliceStable(candidates, func(i, j int) bool {
+			iClose := filepath.Dir(candidates[i]) == sourceDir
+			jClose := filepath.Dir(candidates[j]) == sourceDir
+			if iClose != jClose {
+				return iClose
+			}
+			return strings.Count(candidates[i], string(filepath.Separator)) <
+				strings.Count(candidates[j], string(filepath.Separator))
+		})
+	}
+
+	return candidates
+}
+
+// resolveAnchor checks the heading or block of a link in the note at path
+func (r *resolver) resolveAnchor(link *model.Link, index *index.VaultIndex, path string) model.Kind {
+	// Headings and blocks are only known for notes, attachments like PDFs use #page=N
+	if !index.IsNote(path) {
+		return ""
+	}
+
+	if link.BlockID != "" && !index.HasBlock(path, link.BlockID) {
+		return model.KindBrokenBlock
+	}
+
+	if link.Heading == "" {
+		return ""
+	}
+
+	// Nested anchors like [[Note#Chapter#Section]] must all exist
+	for _, heading := range strings.Split(link.Heading, "#") {
+		if !index.HasHeading(path, heading) {
+			return model.KindBrokenHeading
+		}
+	}
 
-// Resolve checks if a link target exists in the vault index
-func (r *resolver) Resolve(ctx context.Context, link *model.Link, index *index.VaultIndex) bool {
-	// We only check if the target note/file exists
-	// We don't validate headings (per user requirement)
-	return index.Resolve(link.Target)
+	return ""
 }
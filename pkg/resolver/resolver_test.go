@@ -12,6 +12,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
 	"github.com/bborbe/obsidian-lint/pkg/index"
 	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
@@ -29,7 +31,7 @@ var _ = Describe("Resolver", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
-		r = resolver.New()
+		r = resolver.New(config.Default())
 
 		tempDir, err = os.MkdirTemp("", "resolver-test")
 		Expect(err).NotTo(HaveOccurred())
@@ -39,7 +41,16 @@ var _ = Describe("Resolver", func() {
 		note2 := filepath.Join(tempDir, "Note2.md")
 		noteWithAlias := filepath.Join(tempDir, "AliasNote.md")
 
-		Expect(os.WriteFile(note1, []byte("Content"), 0600)).To(Succeed())
+		Expect(os.WriteFile(note1, []byte(`# Introduction
+
+Content
+
+Project Goals
+-------------
+
+## Next Steps: Q1
+
+Key paragraph. ^key-1`), 0600)).To(Succeed())
 		Expect(os.WriteFile(note2, []byte("Content"), 0600)).To(Succeed())
 		Expect(os.WriteFile(noteWithAlias, []byte(`---
 aliases: [MyAlias, Another Alias]
@@ -48,7 +59,7 @@ Content`), 0600)).To(Succeed())
 
 		// Build index
 		p := parser.New()
-		builder := index.New(p)
+		builder := index.New(p, exclude.New(nil, nil), 0)
 		idx, err = builder.Build(ctx, tempDir, []string{note1, note2, noteWithAlias})
 		Expect(err).NotTo(HaveOccurred())
 	})
@@ -65,8 +76,7 @@ Content`), 0600)).To(Succeed())
 				Target: "Note1",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
 		})
 
 		It("resolves link case-insensitively", func() {
@@ -74,8 +84,7 @@ Content`), 0600)).To(Succeed())
 				Target: "note1",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
 		})
 
 		It("resolves link via alias", func() {
@@ -83,8 +92,7 @@ Content`), 0600)).To(Succeed())
 				Target: "MyAlias",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
 		})
 
 		It("resolves link via alias case-insensitively", func() {
@@ -92,8 +100,7 @@ Content`), 0600)).To(Succeed())
 				Target: "another alias",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
 		})
 
 		It("returns false for non-existent target", func() {
@@ -101,19 +108,106 @@ Content`), 0600)).To(Succeed())
 				Target: "DoesNotExist",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeFalse())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(Equal(model.KindBrokenLink))
+		})
+
+		It("resolves link to existing heading", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				Heading: "Introduction",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
 		})
 
-		It("ignores heading when resolving", func() {
+		It("resolves heading case- and whitespace-insensitively", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				Heading: "project  goals",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("resolves heading containing characters not allowed in anchors", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				Heading: "Next Steps Q1",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("resolves nested heading anchors", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				Heading: "Introduction#Project Goals",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("reports broken heading when heading is missing", func() {
 			link := &model.Link{
 				Target:  "Note1",
 				Heading: "SomeHeading",
 			}
 
-			// Should return true even though we don't validate heading
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(Equal(model.KindBrokenHeading))
+		})
+
+		It("reports broken link rather than heading when note is missing", func() {
+			link := &model.Link{
+				Target:  "DoesNotExist",
+				Heading: "Introduction",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(Equal(model.KindBrokenLink))
+		})
+
+		It("resolves block reference", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				BlockID: "key-1",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("reports broken block when block ID is missing", func() {
+			link := &model.Link{
+				Target:  "Note1",
+				BlockID: "deleted",
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(Equal(model.KindBrokenBlock))
+		})
+
+		It("resolves block reference in the same file", func() {
+			link := &model.Link{
+				BlockID: "key-1",
+				Source:  filepath.Join(tempDir, "Note1.md"),
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("resolves heading in the same file", func() {
+			link := &model.Link{
+				Heading: "Introduction",
+				Source:  filepath.Join(tempDir, "Note1.md"),
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		It("reports missing heading in the same file", func() {
+			link := &model.Link{
+				Heading: "Missing",
+				Source:  filepath.Join(tempDir, "Note2.md"),
+			}
+
+			Expect(r.Resolve(ctx, link, idx).Kind).To(Equal(model.KindBrokenHeading))
 		})
 
 		It("ignores alias field when resolving", func() {
@@ -122,8 +216,73 @@ Content`), 0600)).To(Succeed())
 				Alias:  "Display Text",
 			}
 
-			exists := r.Resolve(ctx, link, idx)
-			Expect(exists).To(BeTrue())
+			Expect(r.Resolve(ctx, link, idx).Kind).To(BeEmpty())
+		})
+
+		Context("with duplicate basenames", func() {
+			var (
+				projectMeeting string
+				archiveMeeting string
+				source         string
+			)
+
+			BeforeEach(func() {
+				Expect(os.MkdirAll(filepath.Join(tempDir, "Projects"), 0755)).To(Succeed())
+				Expect(os.MkdirAll(filepath.Join(tempDir, "Archive", "2023"), 0755)).To(Succeed())
+				projectMeeting = filepath.Join(tempDir, "Projects", "Meeting.md")
+				archiveMeeting = filepath.Join(tempDir, "Archive", "2023", "Meeting.md")
+				source = filepath.Join(tempDir, "Archive", "2023", "Index.md")
+				for _, file := range []string{projectMeeting, archiveMeeting, source} {
+					Expect(os.WriteFile(file, []byte("# Agenda"), 0600)).To(Succeed())
+				}
+
+				idx, err = index.New(parser.New(), exclude.New(nil, nil), 0).
+					Build(ctx, tempDir, []string{projectMeeting, archiveMeeting, source})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports ambiguous link with all candidates", func() {
+				link := &model.Link{Target: "Meeting", Source: source}
+
+				result := r.Resolve(ctx, link, idx)
+				Expect(result.Kind).To(Equal(model.KindAmbiguousLink))
+				Expect(result.Candidates).To(Equal([]string{archiveMeeting, projectMeeting}))
+				Expect(result.Path).To(BeEmpty())
+			})
+
+			It("resolves path-qualified link uniquely", func() {
+				link := &model.Link{Target: "Projects/Meeting", Source: source}
+
+				result := r.Resolve(ctx, link, idx)
+				Expect(result.Kind).To(BeEmpty())
+				Expect(result.Path).To(Equal(projectMeeting))
+			})
+
+			It("reports which file Obsidian opens with preferClosest", func() {
+				r = resolver.New(&config.Config{PreferClosest: true})
+				link := &model.Link{Target: "Meeting", Source: filepath.Join(tempDir, "Projects", "x.md")}
+
+				result := r.Resolve(ctx, link, idx)
+				Expect(result.Kind).To(Equal(model.KindAmbiguousLink))
+				Expect(result.Path).To(Equal(projectMeeting))
+				Expect(result.Candidates).To(Equal([]string{projectMeeting, archiveMeeting}))
+			})
+
+			It("prefers the shortest path with preferClosest", func() {
+				r = resolver.New(&config.Config{PreferClosest: true})
+				link := &model.Link{Target: "Meeting", Source: filepath.Join(tempDir, "Other.md")}
+
+				result := r.Resolve(ctx, link, idx)
+				Expect(result.Path).To(Equal(projectMeeting))
+			})
+
+			It("resolves markdown link relative to the linking file", func() {
+				link := &model.Link{Target: "Meeting.md", Source: source, IsMarkdown: true}
+
+				result := r.Resolve(ctx, link, idx)
+				Expect(result.Kind).To(BeEmpty())
+				Expect(result.Path).To(Equal(archiveMeeting))
+			})
 		})
 	})
 })
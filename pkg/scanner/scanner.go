@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 
 	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
 )
 
 //counterfeiter:generate -o ../../mocks/scanner.go --fake-name Scanner . Scanner
@@ -19,14 +21,18 @@ type Scanner interface {
 	Scan(ctx context.Context, vaultPath string) ([]string, error)
 }
 
-// New creates a new Scanner
-func New() Scanner {
-	return &scanner{}
+// New creates a new Scanner that skips paths excluded by matcher
+func New(matcher exclude.Matcher) Scanner {
+	return &scanner{
+		matcher: matcher,
+	}
 }
 
-type scanner struct{}
+type scanner struct {
+	matcher exclude.Matcher
+}
 
-// Scan walks the vault directory and returns all .md files
+// Scan walks the vault directory and returns all .md files that are not excluded
 func (s *scanner) Scan(ctx context.Context, vaultPath string) ([]string, error) {
 	var files []string
 
@@ -40,6 +46,17 @@ func (s *scanner) Scan(ctx context.Context, vaultPath string) ([]string, error)
 			return ctx.Err()
 		}
 
+		relPath, err := filepath.Rel(vaultPath, path)
+		if err != nil {
+			return errors.Wrap(ctx, err, "relative path failed")
+		}
+		if relPath != "." && s.matcher.Match(filepath.ToSlash(relPath)) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Skip directories
 		if d.IsDir() {
 			return nil
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
 	"github.com/bborbe/obsidian-lint/pkg/scanner"
 )
 
@@ -25,7 +26,7 @@ var _ = Describe("Scanner", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
-		s = scanner.New()
+		s = scanner.New(exclude.New(nil, nil))
 
 		tempDir, err = os.MkdirTemp("", "scanner-test")
 		Expect(err).NotTo(HaveOccurred())
@@ -89,6 +90,19 @@ var _ = Describe("Scanner", func() {
 			Expect(files[0]).To(Equal(mdFile))
 		})
 
+		It("skips hidden folders and excluded paths", func() {
+			s = scanner.New(exclude.New([]string{"Archive/"}, []string{"Templates"}))
+			for _, dir := range []string{".obsidian", ".trash", "Archive", "Templates", "Notes"} {
+				Expect(os.MkdirAll(filepath.Join(tempDir, dir), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(tempDir, dir, "note.md"), []byte("content"), 0600)).
+					To(Succeed())
+			}
+
+			files, err := s.Scan(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal([]string{filepath.Join(tempDir, "Notes", "note.md")}))
+		})
+
 		It("returns empty slice when no .md files exist", func() {
 			txtFile := filepath.Join(tempDir, "file.txt")
 			Expect(os.WriteFile(txtFile, []byte("content"), 0600)).To(Succeed())
@@ -0,0 +1,276 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+//counterfeiter:generate -o ../../mocks/schema_checker.go --fake-name SchemaChecker . Checker
+
+// Checker validates the frontmatter of notes against the configured schemas
+type Checker interface {
+	// Check returns the schema violations in the frontmatter of note. Notes with
+	// malformed frontmatter have no violations, the YAML error is reported instead.
+	Check(ctx context.Context, note *model.ParsedFile, idx *index.VaultIndex) []model.BrokenLink
+}
+
+// TypeProperty is the frontmatter property selecting schemas by note type
+const TypeProperty = "type"
+
+// New creates a Checker for schemas
+func New(schemas []config.Schema) Checker {
+	c := &checker{
+		linkRegex: regexp.MustCompile(`^!?\[\[[^\]]+\]\]$`),
+	}
+	for _, schema := range schemas {
+		c.schemas = append(c.schemas, compiledSchema{
+			Schema: schema,
+			paths:  exclude.New(nil, schema.Paths),
+			names:  sortedNames(schema.Properties),
+		})
+	}
+	return c
+}
+
+type checker struct {
+	schemas   []compiledSchema
+	linkRegex *regexp.Regexp
+}
+
+// compiledSchema is a schema with its path matcher and property names in stable order
+type compiledSchema struct {
+	config.Schema
+	paths exclude.Matcher
+	names []string
+}
+
+// applies returns true if the note at relPath is in one of the schema paths or has its type
+func (s compiledSchema) applies(relPath string, frontmatter *model.Frontmatter) bool {
+	if len(s.Paths) > 0 && s.paths.Match(relPath) {
+		return true
+	}
+	if s.Type == "" {
+		return false
+	}
+	property, exists := frontmatter.Property(TypeProperty)
+	if !exists || property.Kind != model.PropertyKindScalar {
+		return false
+	}
+	return strings.EqualFold(property.Values[0].Value, s.Type)
+}
+
+// Check validates the frontmatter of note against all schemas applying to it
+func (c *checker) Check(
+	ctx context.Context,
+	note *model.ParsedFile,
+	idx *index.VaultIndex,
+) []model.BrokenLink {
+	frontmatter := note.Frontmatter
+	if frontmatter == nil {
+		frontmatter = &model.Frontmatter{}
+	}
+	if frontmatter.Error != "" {
+		return nil
+	}
+
+	var violations []model.BrokenLink
+	relPath := idx.RelPath(note.Path)
+	for _, schema := range c.schemas {
+		if !schema.applies(relPath, frontmatter) {
+			continue
+		}
+		for _, name := range schema.names {
+			violations = append(
+				violations,
+				c.checkProperty(frontmatter, name, schema.Properties[name])...,
+			)
+		}
+	}
+	return violations
+}
+
+// checkProperty validates a single property against its definition
+func (c *checker) checkProperty(
+	frontmatter *model.Frontmatter,
+	name string,
+	definition config.Property,
+) []model.BrokenLink {
+	property, exists := frontmatter.Property(name)
+	if !exists || isEmpty(property) {
+		if !definition.Required {
+			return nil
+		}
+		// Missing properties are reported on the opening --- of the frontmatter
+		return []model.BrokenLink{violation(name, model.PropertyValue{Line: 1}, "is required")}
+	}
+
+	valueType := definition.Type
+	switch {
+	case definition.Type == config.PropertyTypeList:
+		if property.Kind != model.PropertyKindList {
+			return []model.BrokenLink{propertyViolation(property, "must be a list")}
+		}
+		valueType = definition.Items
+	case definition.Type != "" && property.Kind != model.PropertyKindScalar:
+		return []model.BrokenLink{propertyViolation(property, "must be "+describe(definition))}
+	}
+
+	var violations []model.BrokenLink
+	for _, value := range property.Values {
+		if value.Tag == "null" {
+			continue
+		}
+		if !c.hasType(value, valueType, definition.Format) {
+			item := definition
+			item.Type = valueType
+			violations = append(violations, violation(name, value, "must be "+describe(item)))
+			continue
+		}
+		if len(definition.Enum) > 0 && !contains(definition.Enum, value.Value) {
+			violations = append(violations, violation(
+				name,
+				value,
+				"must be one of "+strings.Join(definition.Enum, ", "),
+			))
+		}
+	}
+	return violations
+}
+
+// hasType returns true if value is of valueType, any value has the empty type
+func (c *checker) hasType(
+	value model.PropertyValue,
+	valueType config.PropertyType,
+	format string,
+) bool {
+	text := value.Tag == "str" || value.Tag == "timestamp"
+	switch valueType {
+	case config.PropertyTypeString:
+		return text
+	case config.PropertyTypeNumber:
+		return value.Tag == "int" || value.Tag == "float"
+	case config.PropertyTypeInteger:
+		return value.Tag == "int"
+	case config.PropertyTypeBoolean:
+		return value.Tag == "bool"
+	case config.PropertyTypeDate, config.PropertyTypeDateTime:
+		return text && matchesFormat(value.Value, formats(valueType, format))
+	case config.PropertyTypeLink:
+		// Link targets are resolved like all links in properties
+		return value.Tag == "str" && c.linkRegex.MatchString(strings.TrimSpace(value.Value))
+	default:
+		return true
+	}
+}
+
+// defaultFormats lists the accepted formats of dates without configured format
+var defaultFormats = map[config.PropertyType][]string{
+	config.PropertyTypeDate:     {"YYYY-MM-DD"},
+	config.PropertyTypeDateTime: {"YYYY-MM-DDTHH:mm:ss", "YYYY-MM-DDTHH:mm"},
+}
+
+// formats returns the accepted formats of a date or datetime value
+func formats(valueType config.PropertyType, format string) []string {
+	if format != "" {
+		return []string{format}
+	}
+	return defaultFormats[valueType]
+}
+
+// layoutReplacer converts date format tokens as used by Obsidian to Go time layouts
+var layoutReplacer = strings.NewReplacer(
+	"YYYY", "2006",
+	"MM", "01",
+	"DD", "02",
+	"HH", "15",
+	"mm", "04",
+	"ss", "05",
+)
+
+// matchesFormat returns true if value is a date in one of formats
+func matchesFormat(value string, formats []string) bool {
+	for _, format := range formats {
+		if _, err := time.Parse(layoutReplacer.Replace(format), value); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the expected value of a property for messages
+func describe(definition config.Property) string {
+	switch definition.Type {
+	case config.PropertyTypeInteger:
+		return "an integer"
+	case config.PropertyTypeDate:
+		return "a date (" + strings.Join(formats(definition.Type, definition.Format), " or ") + ")"
+	case config.PropertyTypeDateTime:
+		return "a date and time (" +
+			strings.Join(formats(definition.Type, definition.Format), " or ") + ")"
+	case config.PropertyTypeLink:
+		return "a link like [[Note]]"
+	default:
+		return "a " + string(definition.Type)
+	}
+}
+
+// violation returns a schema violation of a property value
+func violation(name string, value model.PropertyValue, detail string) model.BrokenLink {
+	return model.BrokenLink{
+		Link:     value.Value,
+		Line:     value.Line,
+		Column:   value.Column,
+		Kind:     model.KindSchemaViolation,
+		Property: name,
+		Detail:   detail,
+	}
+}
+
+// propertyViolation returns a schema violation of the whole property
+func propertyViolation(property model.Property, detail string) model.BrokenLink {
+	return violation(property.Name, model.PropertyValue{Line: property.Line, Column: 1}, detail)
+}
+
+// isEmpty returns true for properties without value like "status:" or "tags: []"
+func isEmpty(property model.Property) bool {
+	switch property.Kind {
+	case model.PropertyKindList:
+		return len(property.Values) == 0
+	case model.PropertyKindScalar:
+		return property.Values[0].Tag == "null" || property.Values[0].Value == ""
+	default:
+		return false
+	}
+}
+
+// contains returns true if values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedNames returns the property names in alphabetical order
+func sortedNames(properties map[string]config.Property) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
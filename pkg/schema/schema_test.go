@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schema_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		ctx     context.Context
+		p       parser.Parser
+		schemas []config.Schema
+		tempDir string
+		err     error
+		write   func(name string, content string) string
+		check   func(name string) []model.BrokenLink
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		p = parser.New()
+		schemas = []config.Schema{{
+			Paths: []string{"Projects"},
+			Properties: map[string]config.Property{
+				"status": {
+					Type:     config.PropertyTypeString,
+					Required: true,
+					Enum:     []string{"active", "done"},
+				},
+				"due":      {Type: config.PropertyTypeDate},
+				"started":  {Type: config.PropertyTypeDate, Format: "DD.MM.YYYY"},
+				"estimate": {Type: config.PropertyTypeInteger},
+				"owner":    {Type: config.PropertyTypeLink},
+				"tags":     {Type: config.PropertyTypeList, Items: config.PropertyTypeString},
+			},
+		}}
+
+		tempDir, err = os.MkdirTemp("", "schema-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		write = func(name string, content string) string {
+			path := filepath.Join(tempDir, filepath.FromSlash(name))
+			Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed())
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+		check = func(name string) []model.BrokenLink {
+			m := exclude.New(nil, nil)
+			files, err := scanner.New(m).Scan(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			idx, err := index.New(p, m, 0).Build(ctx, tempDir, files)
+			Expect(err).NotTo(HaveOccurred())
+			note, ok := idx.Note(filepath.Join(tempDir, filepath.FromSlash(name)))
+			Expect(ok).To(BeTrue())
+			c := schema.New(schemas)
+			return c.Check(ctx, note, idx)
+		}
+
+		write("People/Alice.md", "")
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("accepts valid frontmatter", func() {
+		write("Projects/Plan.md", `---
+status: active
+due: 2024-01-31
+started: 15.01.2024
+estimate: 3
+owner: "[[Alice]]"
+tags: [work, q1]
+---
+`)
+
+		Expect(check("Projects/Plan.md")).To(BeEmpty())
+	})
+
+	It("reports missing required properties", func() {
+		write("Projects/Plan.md", "# Plan\n")
+
+		Expect(check("Projects/Plan.md")).To(Equal([]model.BrokenLink{{
+			Line:     1,
+			Kind:     model.KindSchemaViolation,
+			Property: "status",
+			Detail:   "is required",
+		}}))
+	})
+
+	It("reports values of wrong type, format or enum", func() {
+		write("Projects/Plan.md", `---
+status: waiting
+due: 31.01.2024
+started: 2024-01-15
+estimate: 2.5
+owner: Bob
+tags: work
+---
+`)
+
+		violations := check("Projects/Plan.md")
+		details := make(map[string]string)
+		for _, violation := range violations {
+			Expect(violation.Kind).To(Equal(model.KindSchemaViolation))
+			details[violation.Property] = violation.Detail
+		}
+		Expect(details).To(Equal(map[string]string{
+			"status":   "must be one of active, done",
+			"due":      "must be a date (YYYY-MM-DD)",
+			"started":  "must be a date (DD.MM.YYYY)",
+			"estimate": "must be an integer",
+			"owner":    "must be a link like [[Note]]",
+			"tags":     "must be a list",
+		}))
+	})
+
+	It("reports the line and column of the value", func() {
+		write("Projects/Plan.md", "---\ntitle: Plan\nstatus: waiting\n---\n")
+
+		violations := check("Projects/Plan.md")
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Line).To(Equal(3))
+		Expect(violations[0].Column).To(Equal(9))
+		Expect(violations[0].Link).To(Equal("waiting"))
+	})
+
+	It("checks list items", func() {
+		write("Projects/Plan.md", "---\nstatus: done\ntags:\n  - work\n  - 2024\n---\n")
+
+		violations := check("Projects/Plan.md")
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Property).To(Equal("tags"))
+		Expect(violations[0].Detail).To(Equal("must be a string"))
+		Expect(violations[0].Line).To(Equal(5))
+	})
+
+	It("selects schemas by type property", func() {
+		schemas = []config.Schema{{
+			Type: "meeting",
+			Properties: map[string]config.Property{
+				"date": {Type: config.PropertyTypeDate, Required: true},
+			},
+		}}
+		write("Meeting.md", "---\ntype: Meeting\n---\n")
+		write("Other.md", "---\ntype: person\n---\n")
+
+		Expect(check("Meeting.md")).To(HaveLen(1))
+		Expect(check("Other.md")).To(BeEmpty())
+	})
+
+	It("ignores notes outside the schema paths", func() {
+		write("Archive/Plan.md", "# Plan\n")
+
+		Expect(check("Archive/Plan.md")).To(BeEmpty())
+	})
+
+	It("ignores malformed frontmatter", func() {
+		write("Projects/Plan.md", "---\nstatus: a: b\n---\n")
+
+		Expect(check("Projects/Plan.md")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+)
+
+//counterfeiter:generate -o ../../mocks/settings_loader.go --fake-name SettingsLoader . Loader
+
+// Loader reads the Obsidian settings of a vault
+type Loader interface {
+	Load(ctx context.Context, vaultPath string) (*Settings, error)
+}
+
+// Settings contains the Obsidian app settings from .obsidian/app.json relevant for linting
+type Settings struct {
+	UserIgnoreFilters    []string `json:"userIgnoreFilters"`    // excluded files: path prefixes or /regex/
+	AttachmentFolderPath string   `json:"attachmentFolderPath"` // folder for new attachments
+	NewLinkFormat        string   `json:"newLinkFormat"`        // shortest, relative or absolute
+}
+
+// LinkFormat returns the format of new links, shortest if not set or unknown
+func (s *Settings) LinkFormat() model.LinkFormat {
+	switch format := model.LinkFormat(s.NewLinkFormat); format {
+	case model.LinkFormatRelative, model.LinkFormatAbsolute:
+		return format
+	default:
+		return model.LinkFormatShortest
+	}
+}
+
+// AttachmentFolder returns the vault-relative attachment folder, or "" if attachments
+// are stored in the vault root or next to their notes ("/", "./" or "./sub")
+func (s *Settings) AttachmentFolder() string {
+	folder := strings.Trim(filepath.ToSlash(s.AttachmentFolderPath), "/")
+	if folder == "" || folder == "." || strings.HasPrefix(folder, "./") {
+		return ""
+	}
+	return folder
+}
+
+// New creates a new Loader
+func New() Loader {
+	return &loader{}
+}
+
+type loader struct{}
+
+// Load reads .obsidian/app.json, a vault without the file has empty settings
+func (l *loader) Load(ctx context.Context, vaultPath string) (*Settings, error) {
+	// #nosec G304 -- path is built from the vault directory
+	content, err := os.ReadFile(filepath.Join(vaultPath, ".obsidian", "app.json"))
+	if os.IsNotExist(err) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "read app.json failed")
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(content, &settings); err != nil {
+		return nil, errors.Wrap(ctx, err, "parse app.json failed")
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package settings_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/settings"
+)
+
+var _ = Describe("Loader", func() {
+	var (
+		ctx     context.Context
+		l       settings.Loader
+		tempDir string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		l = settings.New()
+
+		tempDir, err = os.MkdirTemp("", "settings-test")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	Context("Load", func() {
+		It("reads app.json", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".obsidian"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, ".obsidian", "app.json"), []byte(`{
+  "userIgnoreFilters": ["Archive/", "/\\.excalidraw/"],
+  "attachmentFolderPath": "Attachments",
+  "newLinkFormat": "relative",
+  "showLineNumber": true
+}`), 0600)).To(Succeed())
+
+			s, err := l.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s.UserIgnoreFilters).To(Equal([]string{"Archive/", `/\.excalidraw/`}))
+			Expect(s.AttachmentFolderPath).To(Equal("Attachments"))
+			Expect(s.LinkFormat()).To(Equal(model.LinkFormatRelative))
+		})
+
+		It("returns empty settings without app.json", func() {
+			s, err := l.Load(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(&settings.Settings{}))
+		})
+
+		It("returns error for malformed app.json", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".obsidian"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, ".obsidian", "app.json"), []byte(`{`), 0600)).
+				To(Succeed())
+
+			_, err := l.Load(ctx, tempDir)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("AttachmentFolder", func() {
+		DescribeTable("returns the vault attachment folder",
+			func(attachmentFolderPath string, expected string) {
+				s := &settings.Settings{AttachmentFolderPath: attachmentFolderPath}
+				Expect(s.AttachmentFolder()).To(Equal(expected))
+			},
+			Entry("not set", "", ""),
+			Entry("vault root", "/", ""),
+			Entry("next to note", "./", ""),
+			Entry("subfolder of note folder", "./assets", ""),
+			Entry("vault folder", "Attachments", "Attachments"),
+			Entry("nested vault folder", "Files/Attachments/", "Files/Attachments"),
+		)
+	})
+
+	Context("LinkFormat", func() {
+		DescribeTable("returns the new link format",
+			func(newLinkFormat string, expected model.LinkFormat) {
+				s := &settings.Settings{NewLinkFormat: newLinkFormat}
+				Expect(s.LinkFormat()).To(Equal(expected))
+			},
+			Entry("not set", "", model.LinkFormatShortest),
+			Entry("shortest", "shortest", model.LinkFormatShortest),
+			Entry("relative", "relative", model.LinkFormatRelative),
+			Entry("absolute", "absolute", model.LinkFormatAbsolute),
+			Entry("unknown", "other", model.LinkFormatShortest),
+		)
+	})
+})
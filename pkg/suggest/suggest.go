@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package suggest
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/bborbe/obsidian-lint/pkg/index"
+)
+
+//counterfeiter:generate -o ../../mocks/suggester.go --fake-name Suggester . Suggester
+
+// Suggester finds existing notes, files and aliases similar to a missing link target
+type Suggester interface {
+	Suggest(ctx context.Context, target string, index *index.VaultIndex) []Suggestion
+}
+
+// Suggestion is an existing name similar to a missing link target
+type Suggestion struct {
+	index.Name
+	Distance  int  // edit distance after ignoring case, whitespace and diacritics
+	Confident bool // similar enough to rewrite the link automatically
+}
+
+// New creates a new Suggester
+func New() Suggester {
+	return &suggester{}
+}
+
+type suggester struct{}
+
+// Suggest returns similar names ordered by distance, at most one per file. Only the
+// last path segment of the target is compared, so [[Folder/Nte]] suggests Note.
+// Names within a third of the target length are similar, names within a fifth are
+// confident. Names differing only in case, whitespace or diacritics are always confident.
+func (s *suggester) Suggest(
+	ctx context.Context,
+	target string,
+	idx *index.VaultIndex,
+) []Suggestion {
+	normalized := normalize(path.Base(strings.TrimSuffix(target, ".md")))
+	length := len([]rune(normalized))
+	maxDistance := length / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	best := make(map[string]Suggestion)
+	for _, name := range idx.Names() {
+		candidate := normalize(name.Name)
+		// The distance is at least the difference in length
+		if diff := len([]rune(candidate)) - length; diff > maxDistance || -diff > maxDistance {
+			continue
+		}
+		distance := levenshtein(normalized, candidate)
+		if distance > maxDistance {
+			continue
+		}
+		if existing, exists := best[name.Path]; exists && existing.Distance <= distance {
+			continue
+		}
+		best[name.Path] = Suggestion{
+			Name:      name,
+			Distance:  distance,
+			Confident: distance*5 <= length,
+		}
+	}
+
+	suggestions := make([]Suggestion, 0, len(best))
+	for _, suggestion := range best {
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+		if suggestions[i].Name.Name != suggestions[j].Name.Name {
+			return suggestions[i].Name.Name < suggestions[j].Name.Name
+		}
+		return suggestions[i].Path < suggestions[j].Path
+	})
+
+	return suggestions
+}
+
+// Confident returns the only confident suggestion, or false if there is none or several
+func Confident(suggestions []Suggestion) (Suggestion, bool) {
+	var result Suggestion
+	count := 0
+	for _, suggestion := range suggestions {
+		if suggestion.Confident {
+			result = suggestion
+			count++
+		}
+	}
+	return result, count == 1
+}
+
+// normalize lowercases, strips diacritics and treats - and _ like whitespace
+func normalize(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	stripped, _, err := transform.String(t, name)
+	if err != nil {
+		stripped = name
+	}
+	stripped = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, stripped)
+	return strings.Join(strings.Fields(stripped), " ")
+}
+
+// levenshtein returns the number of rune insertions, deletions and substitutions
+// needed to turn a into b
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package suggest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
+)
+
+var _ = Describe("Suggester", func() {
+	var (
+		ctx     context.Context
+		s       suggest.Suggester
+		idx     *index.VaultIndex
+		tempDir string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		s = suggest.New()
+
+		tempDir, err = os.MkdirTemp("", "suggest-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		files := map[string]string{
+			"Project Plan.md": "",
+			"Café Notes.md":   "",
+			"Meeting A.md":    "",
+			"Meeting B.md":    "",
+			"Person.md":       "---\naliases: [John Doe]\n---\n",
+			"diagram.png":     "",
+		}
+		var notes []string
+		for name, content := range files {
+			path := filepath.Join(tempDir, name)
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			if filepath.Ext(name) == ".md" {
+				notes = append(notes, path)
+			}
+		}
+
+		p := parser.New()
+		idx, err = index.New(p, exclude.New(nil, nil), 0).Build(ctx, tempDir, notes)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	Context("Suggest", func() {
+		It("suggests names with typos", func() {
+			suggestions := s.Suggest(ctx, "Projct Plan", idx)
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions[0].Name.Name).To(Equal("Project Plan"))
+			Expect(suggestions[0].Distance).To(Equal(1))
+			Expect(suggestions[0].Confident).To(BeTrue())
+		})
+
+		It("ignores case, whitespace, dashes and diacritics", func() {
+			suggestions := s.Suggest(ctx, "cafe-notes", idx)
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions[0].Name.Name).To(Equal("Café Notes"))
+			Expect(suggestions[0].Distance).To(Equal(0))
+		})
+
+		It("suggests aliases", func() {
+			suggestions := s.Suggest(ctx, "Jon Doe", idx)
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions[0].Name.Name).To(Equal("John Doe"))
+			Expect(suggestions[0].IsAlias).To(BeTrue())
+		})
+
+		It("suggests attachments", func() {
+			suggestions := s.Suggest(ctx, "diagramm.png", idx)
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions[0].Name.Name).To(Equal("diagram.png"))
+		})
+
+		It("compares only the last path segment", func() {
+			suggestions := s.Suggest(ctx, "Work/Project Plan", idx)
+			Expect(suggestions).To(HaveLen(1))
+			Expect(suggestions[0].Distance).To(Equal(0))
+		})
+
+		It("returns nothing for unrelated targets", func() {
+			Expect(s.Suggest(ctx, "Completely Different", idx)).To(BeEmpty())
+		})
+	})
+
+	Context("Confident", func() {
+		It("returns the only confident suggestion", func() {
+			suggestion, ok := suggest.Confident(s.Suggest(ctx, "Projct Plan", idx))
+			Expect(ok).To(BeTrue())
+			Expect(suggestion.Name.Name).To(Equal("Project Plan"))
+		})
+
+		It("returns false for several confident suggestions", func() {
+			suggestions := s.Suggest(ctx, "Meeting C", idx)
+			Expect(suggestions).To(HaveLen(2))
+			_, ok := suggest.Confident(suggestions)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trash
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// Folder is the vault trash folder used by Obsidian's "Move to Obsidian trash" option
+const Folder = ".trash"
+
+//counterfeiter:generate -o ../../mocks/trash.go --fake-name Trash . Trash
+
+// Trash removes files from the vault without deleting them
+type Trash interface {
+	// Move moves the file at path into the vault trash and returns its new path
+	Move(ctx context.Context, vaultPath string, path string) (string, error)
+}
+
+// New creates a new Trash
+func New() Trash {
+	return &trash{}
+}
+
+type trash struct{}
+
+// Move keeps the vault-relative folder structure inside the trash folder. An
+// existing file with the same name is not overwritten, a number is appended instead.
+func (t *trash) Move(ctx context.Context, vaultPath string, path string) (string, error) {
+	relPath, err := filepath.Rel(vaultPath, path)
+	if err != nil || strings.HasPrefix(filepath.ToSlash(relPath), "../") {
+		return "", errors.Errorf(ctx, "file %s is not inside vault %s", path, vaultPath)
+	}
+
+	target := filepath.Join(vaultPath, Folder, relPath)
+	if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+		return "", errors.Wrap(ctx, err, "create trash folder failed")
+	}
+
+	ext := filepath.Ext(target)
+	base := strings.TrimSuffix(target, ext)
+	for i := 1; fileExists(target); i++ {
+		target = fmt.Sprintf("%s %d%s", base, i, ext)
+	}
+
+	if err := os.Rename(path, target); err != nil {
+		return "", errors.Wrap(ctx, err, "move to trash failed")
+	}
+
+	return target, nil
+}
+
+// fileExists returns true if a file or folder exists at path
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package trash_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/obsidian-lint/pkg/trash"
+)
+
+var _ = Describe("Trash", func() {
+	var (
+		ctx     context.Context
+		t       trash.Trash
+		tempDir string
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		t = trash.New()
+
+		tempDir, err = os.MkdirTemp("", "trash-test")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	})
+
+	Context("Move", func() {
+		It("moves file into trash folder keeping its folder", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Attachments"), 0750)).To(Succeed())
+			file := filepath.Join(tempDir, "Attachments", "image.png")
+			Expect(os.WriteFile(file, []byte("png"), 0600)).To(Succeed())
+
+			target, err := t.Move(ctx, tempDir, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal(filepath.Join(tempDir, ".trash", "Attachments", "image.png")))
+			Expect(target).To(BeARegularFile())
+			Expect(file).NotTo(BeAnExistingFile())
+		})
+
+		It("does not overwrite files already in trash", func() {
+			file := filepath.Join(tempDir, "image.png")
+			Expect(os.MkdirAll(filepath.Join(tempDir, ".trash"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, ".trash", "image.png"), []byte("old"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(file, []byte("new"), 0600)).To(Succeed())
+
+			target, err := t.Move(ctx, tempDir, file)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal(filepath.Join(tempDir, ".trash", "image 1.png")))
+		})
+
+		It("rejects files outside the vault", func() {
+			_, err := t.Move(ctx, tempDir, filepath.Join(filepath.Dir(tempDir), "other.png"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
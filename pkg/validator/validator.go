@@ -6,14 +6,23 @@ package validator
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/bborbe/errors"
 
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/git"
 	"github.com/bborbe/obsidian-lint/pkg/index"
 	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/pool"
 	"github.com/bborbe/obsidian-lint/pkg/resolver"
 	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
 )
 
 //counterfeiter:generate -o ../../mocks/validator.go --fake-name Validator . Validator
@@ -21,6 +30,24 @@ import (
 // Validator orchestrates vault scanning and link validation
 type Validator interface {
 	Validate(ctx context.Context, vaultPath string) (*model.ValidationResult, error)
+	// ValidateDiff validates the whole vault but only reports findings of changed
+	// files and of notes linking to files removed in diff
+	ValidateDiff(
+		ctx context.Context,
+		vaultPath string,
+		diff *git.Diff,
+	) (*model.ValidationResult, error)
+	// ValidateIndex validates the notes of an index built before, e.g. one kept up
+	// to date while watching the vault
+	ValidateIndex(ctx context.Context, idx *index.VaultIndex) (*model.ValidationResult, error)
+	// ValidateNotes validates only the notes at paths of idx, e.g. the documents open
+	// in an editor. Orphan notes and unused attachments need the links of the whole
+	// vault and are not reported.
+	ValidateNotes(
+		ctx context.Context,
+		idx *index.VaultIndex,
+		paths []string,
+	) (*model.ValidationResult, error)
 }
 
 // New creates a new Validator
@@ -29,26 +56,82 @@ func New(
 	parser parser.Parser,
 	indexBuilder index.Builder,
 	resolver resolver.Resolver,
+	suggester suggest.Suggester,
+	schemaChecker schema.Checker,
+	cfg *config.Config,
 ) Validator {
 	return &validator{
-		scanner:      scanner,
-		parser:       parser,
-		indexBuilder: indexBuilder,
-		resolver:     resolver,
+		scanner:       scanner,
+		parser:        parser,
+		indexBuilder:  indexBuilder,
+		resolver:      resolver,
+		suggester:     suggester,
+		schemaChecker: schemaChecker,
+		cfg:           cfg,
 	}
 }
 
 type validator struct {
-	scanner      scanner.Scanner
-	parser       parser.Parser
-	indexBuilder index.Builder
-	resolver     resolver.Resolver
+	scanner       scanner.Scanner
+	parser        parser.Parser
+	indexBuilder  index.Builder
+	resolver      resolver.Resolver
+	suggester     suggest.Suggester
+	schemaChecker schema.Checker
+	cfg           *config.Config
 }
 
 // Validate scans vault and returns broken links
 func (v *validator) Validate(
 	ctx context.Context,
 	vaultPath string,
+) (*model.ValidationResult, error) {
+	return v.validate(ctx, vaultPath, nil)
+}
+
+// ValidateDiff scans vault and returns the broken links of changed notes
+func (v *validator) ValidateDiff(
+	ctx context.Context,
+	vaultPath string,
+	diff *git.Diff,
+) (*model.ValidationResult, error) {
+	return v.validate(ctx, vaultPath, diff)
+}
+
+// ValidateIndex returns the broken links of all notes in idx
+func (v *validator) ValidateIndex(
+	ctx context.Context,
+	idx *index.VaultIndex,
+) (*model.ValidationResult, error) {
+	return v.check(ctx, idx, nil)
+}
+
+// ValidateNotes returns the broken links of the notes at paths of idx
+func (v *validator) ValidateNotes(
+	ctx context.Context,
+	idx *index.VaultIndex,
+	paths []string,
+) (*model.ValidationResult, error) {
+	result := v.newResult(idx, nil)
+	for _, path := range paths {
+		note, exists := idx.Note(path)
+		if !exists {
+			continue
+		}
+		result.Files = append(result.Files, path)
+		fileResult := v.validateFile(ctx, note, idx)
+		// Orphan notes are not known, so their suppressions can not be unused
+		fileResult.assumeUsed(model.KindOrphanNote)
+		v.addFindings(result, fileResult)
+	}
+	return result, nil
+}
+
+// validate scans and indexes the whole vault and restricts the result to diff if set
+func (v *validator) validate(
+	ctx context.Context,
+	vaultPath string,
+	diff *git.Diff,
 ) (*model.ValidationResult, error) {
 	// Scan vault for markdown files
 	files, err := v.scanner.Scan(ctx, vaultPath)
@@ -62,26 +145,431 @@ func (v *validator) Validate(
 		return nil, errors.Wrap(ctx, err, "build index failed")
 	}
 
-	// Validate links in each file
-	result := &model.ValidationResult{
+	// Staged notes are validated with their staged content
+	if diff != nil {
+		for path, content := range diff.Contents {
+			if _, exists := idx.Note(path); !exists {
+				continue
+			}
+			note, err := v.parser.ParseContent(ctx, path, content)
+			if err != nil {
+				return nil, errors.Wrapf(ctx, err, "parse staged %s failed", path)
+			}
+			idx.Add(path, note)
+		}
+	}
+
+	return v.check(ctx, idx, diff)
+}
+
+// check validates all notes of idx and restricts the result to diff if set
+func (v *validator) check(
+	ctx context.Context,
+	idx *index.VaultIndex,
+	diff *git.Diff,
+) (*model.ValidationResult, error) {
+	// Validate links in each file in parallel and merge the incoming links for
+	// orphan detection afterwards
+	notes := idx.Notes()
+	fileResults := make([]*fileResult, len(notes))
+	err := pool.Run(ctx, v.cfg.Workers, len(notes), func(ctx context.Context, i int) error {
+		fileResults[i] = v.validateFile(ctx, notes[i], idx)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "validate files failed")
+	}
+	inbound := make(map[string]struct{})
+	for _, fileResult := range fileResults {
+		for target := range fileResult.inbound {
+			inbound[target] = struct{}{}
+		}
+	}
+
+	// Canvas files only count as incoming links, their links are not validated
+	for _, file := range idx.Files() {
+		if filepath.Ext(file) != ".canvas" {
+			continue
+		}
+		links, err := v.parser.ParseCanvas(ctx, file)
+		if err != nil {
+			return nil, errors.Wrap(ctx, err, "parse canvas failed")
+		}
+		for _, link := range links {
+			addInbound(inbound, file, v.resolver.Resolve(ctx, link, idx))
+		}
+	}
+
+	files := make([]string, 0, len(notes))
+	for _, note := range notes {
+		files = append(files, note.Path)
+	}
+	result := v.newResult(idx, files)
+	orphanIgnore := exclude.New(nil, v.cfg.Orphans.Ignore)
+	for _, fileResult := range fileResults {
+		if v.cfg.Enabled(model.KindOrphanNote) &&
+			v.isOrphan(fileResult, idx, inbound, orphanIgnore) {
+			fileResult.record(model.BrokenLink{
+				Kind:     model.KindOrphanNote,
+				Severity: v.cfg.Severity(model.KindOrphanNote),
+			})
+		}
+		v.addFindings(result, fileResult)
+	}
+
+	if v.cfg.Enabled(model.KindUnusedAttachment) {
+		if err := v.addUnusedAttachments(ctx, result, idx, inbound); err != nil {
+			return nil, err
+		}
+	}
+
+	if diff != nil {
+		restrict(result, idx, diff)
+	}
+
+	return result, nil
+}
+
+// newResult returns an empty result for files of idx
+func (v *validator) newResult(idx *index.VaultIndex, files []string) *model.ValidationResult {
+	return &model.ValidationResult{
+		VaultPath:   idx.VaultPath(),
+		Files:       files,
 		BrokenLinks: make(map[string][]model.BrokenLink),
+		Severities:  v.cfg.Severities(),
+	}
+}
+
+// addFindings reports the unused suppressions of a file and adds its findings to result
+func (v *validator) addFindings(result *model.ValidationResult, fileResult *fileResult) {
+	if v.cfg.Enabled(model.KindUnusedSuppression) {
+		fileResult.reportUnusedSuppressions(
+			v.cfg.Severity(model.KindUnusedSuppression),
+			result.Severities,
+		)
+	}
+	if len(fileResult.findings) > 0 {
+		result.BrokenLinks[fileResult.file] = fileResult.findings
+	}
+}
+
+// restrict removes files and findings of files that neither changed in diff nor
+// link to a file removed in diff
+func restrict(result *model.ValidationResult, idx *index.VaultIndex, diff *git.Diff) {
+	selected := make(map[string]struct{}, len(diff.Changed))
+	for _, path := range diff.Changed {
+		selected[path] = struct{}{}
+	}
+	for _, note := range idx.Notes() {
+		if linksToAny(idx, note.Links, diff.Removed) {
+			selected[note.Path] = struct{}{}
+		}
+	}
+
+	files := make([]string, 0, len(selected))
+	for _, file := range result.Files {
+		if _, ok := selected[file]; ok {
+			files = append(files, file)
+		}
+	}
+	result.Files = files
+	for file := range result.BrokenLinks {
+		if _, ok := selected[file]; !ok {
+			delete(result.BrokenLinks, file)
+		}
+	}
+}
+
+// linksToAny returns true if one of links targets one of paths
+func linksToAny(idx *index.VaultIndex, links []*model.Link, paths []string) bool {
+	for _, link := range links {
+		for _, path := range paths {
+			if idx.LinksTo(link, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attachmentExtensions lists the file types Obsidian embeds as attachments
+var attachmentExtensions = map[string]struct{}{
+	".avif": {}, ".bmp": {}, ".gif": {}, ".jpeg": {}, ".jpg": {}, ".png": {}, ".svg": {},
+	".webp": {}, ".flac": {}, ".m4a": {}, ".mp3": {}, ".ogg": {}, ".wav": {}, ".3gp": {},
+	".webm": {}, ".mkv": {}, ".mov": {}, ".mp4": {}, ".ogv": {}, ".pdf": {},
+}
+
+// isAttachment returns true for files Obsidian embeds and for all files in the
+// configured attachment folder, other files like scripts are never reported
+func (v *validator) isAttachment(idx *index.VaultIndex, file string) bool {
+	if idx.IsNote(file) || filepath.Ext(file) == ".canvas" {
+		return false
+	}
+	if _, ok := attachmentExtensions[strings.ToLower(filepath.Ext(file))]; ok {
+		return true
 	}
+	folder := strings.Trim(v.cfg.Attachments.Folder, "/")
+	return folder != "" && strings.HasPrefix(idx.RelPath(file), folder+"/")
+}
 
-	for _, file := range files {
-		links, err := v.parser.ParseFile(ctx, file)
+// addUnusedAttachments reports all attachments without incoming link
+func (v *validator) addUnusedAttachments(
+	ctx context.Context,
+	result *model.ValidationResult,
+	idx *index.VaultIndex,
+	inbound map[string]struct{},
+) error {
+	for _, file := range idx.Files() {
+		if !v.isAttachment(idx, file) {
+			continue
+		}
+		if _, linked := inbound[file]; linked {
+			continue
+		}
+		info, err := os.Stat(file)
 		if err != nil {
-			return nil, errors.Wrap(ctx, err, "parse file failed")
+			return errors.Wrap(ctx, err, "stat attachment failed")
 		}
+		result.BrokenLinks[file] = []model.BrokenLink{{
+			Kind:     model.KindUnusedAttachment,
+			Severity: v.cfg.Severity(model.KindUnusedAttachment),
+			Size:     info.Size(),
+		}}
+	}
+	return nil
+}
 
-		for _, link := range links {
-			if !v.resolver.Resolve(ctx, link, idx) {
-				result.BrokenLinks[file] = append(result.BrokenLinks[file], model.BrokenLink{
-					Link: link.Raw,
-					Line: link.Line,
-				})
+// fileResult collects the findings of a file and tracks which suppressions were used
+type fileResult struct {
+	file         string
+	tags         []string
+	findings     []model.BrokenLink
+	suppressions []model.Suppression
+	used         []bool
+	inbound      map[string]struct{} // files the links of the file open
+}
+
+// record adds a finding unless a suppression covers it
+func (f *fileResult) record(finding model.BrokenLink) {
+	suppressed := false
+	for i, suppression := range f.suppressions {
+		if suppression.Suppresses(finding.Kind, finding.Line) {
+			f.used[i] = true
+			suppressed = true
+		}
+	}
+	if !suppressed {
+		f.findings = append(f.findings, finding)
+	}
+}
+
+// assumeUsed marks the suppressions covering file level findings of kind as used
+func (f *fileResult) assumeUsed(kind model.Kind) {
+	for i, suppression := range f.suppressions {
+		if suppression.Suppresses(kind, 0) {
+			f.used[i] = true
+		}
+	}
+}
+
+// reportUnusedSuppressions adds a finding for every suppression that suppressed nothing
+// or names a kind that is not in known, such a kind can never match a finding
+func (f *fileResult) reportUnusedSuppressions(
+	severity model.Severity,
+	known map[model.Kind]model.Severity,
+) {
+	for i, suppression := range f.suppressions {
+		var unknown []string
+		for _, kind := range suppression.Kinds {
+			if _, exists := known[kind]; !exists {
+				unknown = append(unknown, string(kind))
 			}
 		}
+		if f.used[i] && len(unknown) == 0 {
+			continue
+		}
+		finding := model.BrokenLink{
+			Link:     suppression.Raw,
+			Line:     suppression.Line,
+			Kind:     model.KindUnusedSuppression,
+			Severity: severity,
+		}
+		if len(unknown) > 0 {
+			finding.Detail = "unknown kind " + strings.Join(unknown, ", ")
+		}
+		f.findings = append(f.findings, finding)
 	}
+}
 
-	return result, nil
+// validateFile resolves all links of a parsed note, checks its frontmatter and applies
+// config and suppressions
+func (v *validator) validateFile(
+	ctx context.Context,
+	note *model.ParsedFile,
+	idx *index.VaultIndex,
+) *fileResult {
+	fileResult := &fileResult{
+		file:         note.Path,
+		tags:         note.Tags,
+		suppressions: note.Suppressions,
+		used:         make([]bool, len(note.Suppressions)),
+		inbound:      make(map[string]struct{}),
+	}
+	for _, link := range note.Links {
+		resolved := v.resolver.Resolve(ctx, link, idx)
+		addInbound(fileResult.inbound, note.Path, resolved)
+		if link.IsHTML {
+			// HTML src and href are not validated, they only mark the files as used
+			continue
+		}
+		if brokenLink, ok := v.finding(ctx, link, resolved, idx); ok {
+			fileResult.record(brokenLink)
+		}
+	}
+
+	if note.Frontmatter != nil && note.Frontmatter.Error != "" &&
+		v.cfg.Enabled(model.KindMalformedFrontmatter) {
+		fileResult.record(model.BrokenLink{
+			Line:     note.Frontmatter.ErrorLine,
+			Kind:     model.KindMalformedFrontmatter,
+			Severity: v.cfg.Severity(model.KindMalformedFrontmatter),
+			Detail:   note.Frontmatter.Error,
+		})
+	}
+	if v.cfg.Enabled(model.KindSchemaViolation) {
+		for _, violation := range v.schemaChecker.Check(ctx, note, idx) {
+			violation.Severity = v.cfg.Severity(model.KindSchemaViolation)
+			fileResult.record(violation)
+		}
+	}
+
+	return fileResult
+}
+
+// addInbound marks the files a link from source opens as linked. Ambiguous links
+// count for all candidates, so no note is reported as orphan by mistake.
+func addInbound(inbound map[string]struct{}, source string, resolved resolver.Result) {
+	targets := resolved.Candidates
+	if resolved.Path != "" {
+		targets = []string{resolved.Path}
+	}
+	for _, target := range targets {
+		if target != source {
+			inbound[target] = struct{}{}
+		}
+	}
+}
+
+// finding returns the finding for a resolved link, or false if the link is fine or not reported
+func (v *validator) finding(
+	ctx context.Context,
+	link *model.Link,
+	resolved resolver.Result,
+	idx *index.VaultIndex,
+) (model.BrokenLink, bool) {
+	if resolved.Kind == "" || !v.cfg.Enabled(resolved.Kind) {
+		return model.BrokenLink{}, false
+	}
+	if resolved.Kind == model.KindBrokenLink && v.cfg.IsAllowedTarget(link.Target) {
+		return model.BrokenLink{}, false
+	}
+
+	brokenLink := model.BrokenLink{
+		Link:           link.Raw,
+		Line:           link.Line,
+		Column:         link.Column,
+		EndColumn:      link.EndColumn,
+		ColumnUTF16:    link.ColumnUTF16,
+		EndColumnUTF16: link.EndColumnUTF16,
+		Offset:         link.Offset,
+		EndOffset:      link.EndOffset,
+		Kind:           resolved.Kind,
+		Severity:       v.cfg.Severity(resolved.Kind),
+		Property:       link.Property,
+	}
+	if resolved.Kind == model.KindAmbiguousLink {
+		for _, candidate := range resolved.Candidates {
+			brokenLink.Candidates = append(brokenLink.Candidates, idx.RelPath(candidate))
+		}
+		if resolved.Path != "" {
+			brokenLink.Opens = idx.RelPath(resolved.Path)
+		}
+	}
+	if resolved.Kind == model.KindBrokenLink {
+		suggestions := v.suggester.Suggest(ctx, link.Target, idx)
+		if suggestion, ok := suggest.Confident(suggestions); ok {
+			brokenLink.Fix = link.WithTarget(linkTarget(link, suggestion, idx, v.cfg.LinkFormat))
+		}
+		for i := 0; i < len(suggestions) && i < v.cfg.Suggestions; i++ {
+			brokenLink.Suggestions = append(
+				brokenLink.Suggestions,
+				linkTarget(link, suggestions[i], idx, v.cfg.LinkFormat),
+			)
+		}
+	}
+
+	return brokenLink, true
+}
+
+// linkTarget returns the link target opening the suggested file in the link format.
+// Markdown links use the vault path if the format is absolute, otherwise the path
+// relative to the linking file. Wiki links use the unique name, the vault path or
+// the ./ or ../ path relative to the linking file.
+func linkTarget(
+	link *model.Link,
+	suggestion suggest.Suggestion,
+	idx *index.VaultIndex,
+	format model.LinkFormat,
+) string {
+	relPath, err := filepath.Rel(filepath.Dir(link.Source), suggestion.Path)
+	relative := err == nil && format != model.LinkFormatAbsolute
+	if link.IsMarkdown {
+		if relative {
+			return filepath.ToSlash(relPath)
+		}
+		return idx.RelPath(suggestion.Path)
+	}
+
+	trim := func(target string) string {
+		if idx.IsNote(suggestion.Path) {
+			return strings.TrimSuffix(target, ".md")
+		}
+		return target
+	}
+	if relative && format == model.LinkFormatRelative {
+		relPath = filepath.ToSlash(relPath)
+		if !strings.HasPrefix(relPath, "../") {
+			relPath = "./" + relPath
+		}
+		return trim(relPath)
+	}
+	if format != model.LinkFormatAbsolute {
+		candidates := idx.Candidates(suggestion.Name.Name)
+		if len(candidates) == 1 && candidates[0] == suggestion.Path {
+			return suggestion.Name.Name
+		}
+	}
+	return trim(idx.RelPath(suggestion.Path))
+}
+
+// isOrphan returns true if no other note links to the file and it is neither
+// ignored nor tagged as entry point
+func (v *validator) isOrphan(
+	fileResult *fileResult,
+	idx *index.VaultIndex,
+	inbound map[string]struct{},
+	orphanIgnore exclude.Matcher,
+) bool {
+	if _, linked := inbound[fileResult.file]; linked {
+		return false
+	}
+	if orphanIgnore.Match(idx.RelPath(fileResult.file)) {
+		return false
+	}
+	for _, tag := range fileResult.tags {
+		if v.cfg.IsRootTag(tag) {
+			return false
+		}
+	}
+	return true
 }
@@ -12,10 +12,16 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/git"
 	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
 	"github.com/bborbe/obsidian-lint/pkg/parser"
 	"github.com/bborbe/obsidian-lint/pkg/resolver"
 	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
 	"github.com/bborbe/obsidian-lint/pkg/validator"
 )
 
@@ -31,11 +37,12 @@ var _ = Describe("Validator", func() {
 		ctx = context.Background()
 
 		// Create real implementations
-		s := scanner.New()
+		m := exclude.New(nil, nil)
+		s := scanner.New(m)
 		p := parser.New()
-		b := index.New(p)
-		r := resolver.New()
-		v = validator.New(s, p, b, r)
+		b := index.New(p, m, 0)
+		r := resolver.New(config.Default())
+		v = validator.New(s, p, b, r, suggest.New(), schema.New(nil), config.Default())
 
 		tempDir, err = os.MkdirTemp("", "validator-test")
 		Expect(err).NotTo(HaveOccurred())
@@ -60,6 +67,21 @@ var _ = Describe("Validator", func() {
 			Expect(result.BrokenLinks[note]).To(HaveLen(1))
 			Expect(result.BrokenLinks[note][0].Link).To(Equal("[[DoesNotExist]]"))
 			Expect(result.BrokenLinks[note][0].Line).To(Equal(1))
+			Expect(result.BrokenLinks[note][0].Kind).To(Equal(model.KindBrokenLink))
+		})
+
+		It("detects broken links in frontmatter properties", func() {
+			note := filepath.Join(tempDir, "Note.md")
+			content := "---\ntitle: Note\nrelated:\n  - \"[[Note]]\"\n  - \"[[Gone]]\"\n---\n"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note][0].Link).To(Equal("[[Gone]]"))
+			Expect(result.BrokenLinks[note][0].Property).To(Equal("related"))
+			Expect(result.BrokenLinks[note][0].Line).To(Equal(5))
+			Expect(result.BrokenLinks[note][0].Column).To(Equal(6))
 		})
 
 		It("allows valid link to existing note", func() {
@@ -137,7 +159,7 @@ Content`), 0600)).To(Succeed())
 			Expect(result.BrokenLinks[note2]).To(HaveLen(1))
 		})
 
-		It("ignores heading when validating", func() {
+		It("detects broken heading", func() {
 			note1 := filepath.Join(tempDir, "Note1.md")
 			note2 := filepath.Join(tempDir, "Note2.md")
 
@@ -147,10 +169,399 @@ Content`), 0600)).To(Succeed())
 
 			result, err := v.Validate(ctx, tempDir)
 			Expect(err).NotTo(HaveOccurred())
-			// Should be valid because we only check if Note2 exists
+			Expect(result.BrokenLinks[note1]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note1][0].Link).To(Equal("[[Note2#NonExistentHeading]]"))
+			Expect(result.BrokenLinks[note1][0].Kind).To(Equal(model.KindBrokenHeading))
+		})
+
+		It("allows link to existing heading", func() {
+			note1 := filepath.Join(tempDir, "Note1.md")
+			note2 := filepath.Join(tempDir, "Note2.md")
+
+			Expect(os.WriteFile(note1, []byte("[[Note2#Milestones]] and [[#Intro]]\n# Intro"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(note2, []byte("# Plan\n\n## Milestones\n"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
 			Expect(result.BrokenLinks).To(BeEmpty())
 		})
 
+		It("detects dangling block reference", func() {
+			note1 := filepath.Join(tempDir, "Note1.md")
+			note2 := filepath.Join(tempDir, "Note2.md")
+
+			Expect(os.WriteFile(note1, []byte("![[Note2#^kept]]\n![[Note2#^deleted]]"), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(note2, []byte("Kept paragraph ^kept"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note1]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note1][0].Link).To(Equal("![[Note2#^deleted]]"))
+			Expect(result.BrokenLinks[note1][0].Line).To(Equal(2))
+			Expect(result.BrokenLinks[note1][0].Kind).To(Equal(model.KindBrokenBlock))
+		})
+
+		It("ignores links in code and comments", func() {
+			note := filepath.Join(tempDir, "Note.md")
+			content := "```\n[[InFence]]\n```\nInline `[[InCode]]` %% [[InComment]] %%"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks).To(BeEmpty())
+		})
+
+		It("validates markdown links", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "sub"), 0755)).To(Succeed())
+			note1 := filepath.Join(tempDir, "sub", "Note1.md")
+			note2 := filepath.Join(tempDir, "Some Note.md")
+
+			content := "[ok](../Some%20Note.md#Intro)\n[broken](Missing.md)\n" +
+				"[heading](<../Some Note.md#Gone>)\n[web](https://example.com)"
+			Expect(os.WriteFile(note1, []byte(content), 0600)).To(Succeed())
+			Expect(os.WriteFile(note2, []byte("# Intro"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks).To(HaveLen(1))
+			Expect(result.BrokenLinks[note1]).To(HaveLen(2))
+			Expect(result.BrokenLinks[note1][0].Link).To(Equal("[broken](Missing.md)"))
+			Expect(result.BrokenLinks[note1][0].Kind).To(Equal(model.KindBrokenLink))
+			Expect(result.BrokenLinks[note1][1].Link).To(Equal("[heading](<../Some Note.md#Gone>)"))
+			Expect(result.BrokenLinks[note1][1].Kind).To(Equal(model.KindBrokenHeading))
+		})
+
+		It("resolves path-qualified links", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Projects", "2024"), 0755)).To(Succeed())
+			note := filepath.Join(tempDir, "Projects", "Index.md")
+			plan := filepath.Join(tempDir, "Projects", "2024", "Plan.md")
+
+			content := "[[Projects/2024/Plan]] [[2024/Plan#Goals]] [[./2024/Plan]]\n" +
+				"[[Projects/2023/Plan]] [[../Plan]]"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+			Expect(os.WriteFile(plan, []byte("# Goals"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks).To(HaveLen(1))
+			Expect(result.BrokenLinks[note]).To(HaveLen(2))
+			Expect(result.BrokenLinks[note][0].Link).To(Equal("[[Projects/2023/Plan]]"))
+			Expect(result.BrokenLinks[note][1].Link).To(Equal("[[../Plan]]"))
+		})
+
+		It("reports ambiguous links with vault-relative candidates", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "A"), 0755)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "B"), 0755)).To(Succeed())
+			note := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(note, []byte("[[Meeting]] [[A/Meeting]]"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "A", "Meeting.md"), []byte(""), 0600)).
+				To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "B", "Meeting.md"), []byte(""), 0600)).
+				To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note][0].Link).To(Equal("[[Meeting]]"))
+			Expect(result.BrokenLinks[note][0].Kind).To(Equal(model.KindAmbiguousLink))
+			Expect(result.BrokenLinks[note][0].Candidates).
+				To(Equal([]string{"A/Meeting.md", "B/Meeting.md"}))
+			Expect(result.BrokenLinks[note][0].Opens).To(BeEmpty())
+		})
+
+		Context("with config", func() {
+			var cfg *config.Config
+
+			BeforeEach(func() {
+				cfg = config.Default()
+				m := exclude.New(nil, nil)
+				p := parser.New()
+				b := index.New(p, m, 0)
+				r := resolver.New(cfg)
+				c := schema.New(cfg.Schemas)
+				v = validator.New(scanner.New(m), p, b, r, suggest.New(), c, cfg)
+			})
+
+			DescribeTable("computes fixes in the link format",
+				func(format model.LinkFormat, wiki string, markdown string) {
+					cfg.LinkFormat = format
+					Expect(os.MkdirAll(filepath.Join(tempDir, "Work"), 0750)).To(Succeed())
+					Expect(os.MkdirAll(filepath.Join(tempDir, "Notes"), 0750)).To(Succeed())
+					plan := filepath.Join(tempDir, "Work", "Project Plan.md")
+					Expect(os.WriteFile(plan, nil, 0600)).To(Succeed())
+					note := filepath.Join(tempDir, "Notes", "Note.md")
+					content := "[[Projct Plan]] [plan](../Work/Projct%20Plan.md)"
+					Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+					result, err := v.Validate(ctx, tempDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.BrokenLinks[note]).To(HaveLen(2))
+					Expect(result.BrokenLinks[note][0].Fix).To(Equal(wiki))
+					Expect(result.BrokenLinks[note][1].Fix).To(Equal(markdown))
+				},
+				Entry("shortest", model.LinkFormatShortest,
+					"[[Project Plan]]", "[plan](../Work/Project%20Plan.md)"),
+				Entry("relative", model.LinkFormatRelative,
+					"[[../Work/Project Plan]]", "[plan](../Work/Project%20Plan.md)"),
+				Entry("absolute", model.LinkFormatAbsolute,
+					"[[Work/Project Plan]]", "[plan](Work/Project%20Plan.md)"),
+			)
+
+			It("applies configured severities", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindBrokenHeading: {Severity: model.SeverityWarning},
+				}
+				note := filepath.Join(tempDir, "Note.md")
+				Expect(os.WriteFile(note, []byte("[[Missing]] [[Note#Gone]]"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks[note]).To(HaveLen(2))
+				Expect(result.BrokenLinks[note][0].Severity).To(Equal(model.SeverityError))
+				Expect(result.BrokenLinks[note][1].Severity).To(Equal(model.SeverityWarning))
+			})
+
+			It("skips disabled checks", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindBrokenHeading: {Severity: model.SeverityOff},
+				}
+				note := filepath.Join(tempDir, "Note.md")
+				Expect(os.WriteFile(note, []byte("[[Note#Gone]]"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks).To(BeEmpty())
+			})
+
+			It("reports malformed frontmatter", func() {
+				note := filepath.Join(tempDir, "Note.md")
+				content := "---\ntitle: Note\nstatus: a: b\n---\n[[Note]]"
+				Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks[note]).To(Equal([]model.BrokenLink{{
+					Line:     3,
+					Kind:     model.KindMalformedFrontmatter,
+					Severity: model.SeverityWarning,
+					Detail:   "mapping values are not allowed in this context",
+				}}))
+			})
+
+			It("reports schema violations", func() {
+				cfg.Schemas = []config.Schema{{
+					Paths: []string{"Projects"},
+					Properties: map[string]config.Property{
+						"status": {Required: true},
+					},
+				}}
+				m := exclude.New(nil, nil)
+				p := parser.New()
+				r := resolver.New(cfg)
+				c := schema.New(cfg.Schemas)
+				v = validator.New(scanner.New(m), p, index.New(p, m, 0), r, suggest.New(), c, cfg)
+				Expect(os.MkdirAll(filepath.Join(tempDir, "Projects"), 0750)).To(Succeed())
+				note := filepath.Join(tempDir, "Projects", "Plan.md")
+				Expect(os.WriteFile(note, []byte("# Plan"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks[note]).To(Equal([]model.BrokenLink{{
+					Line:     1,
+					Kind:     model.KindSchemaViolation,
+					Severity: model.SeverityError,
+					Property: "status",
+					Detail:   "is required",
+				}}))
+			})
+
+			It("reports orphan notes when enabled", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindOrphanNote: {Severity: model.SeverityWarning},
+				}
+				cfg.Orphans = config.Orphans{Ignore: []string{"Daily"}, Tags: []string{"root"}}
+				home := filepath.Join(tempDir, "Home.md")
+				linked := filepath.Join(tempDir, "Linked.md")
+				orphan := filepath.Join(tempDir, "Orphan.md")
+				self := filepath.Join(tempDir, "Self.md")
+				Expect(os.MkdirAll(filepath.Join(tempDir, "Daily"), 0750)).To(Succeed())
+				daily := filepath.Join(tempDir, "Daily", "2024-01-01.md")
+				Expect(os.WriteFile(home, []byte("#root [[Linked]]"), 0600)).To(Succeed())
+				Expect(os.WriteFile(linked, []byte("Back to [[Home]]"), 0600)).To(Succeed())
+				Expect(os.WriteFile(orphan, []byte("Nobody links here"), 0600)).To(Succeed())
+				Expect(os.WriteFile(self, []byte("[[#Top]]\n# Top"), 0600)).To(Succeed())
+				Expect(os.WriteFile(daily, []byte("Log"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks).To(HaveLen(2))
+				Expect(result.BrokenLinks[orphan]).To(Equal([]model.BrokenLink{
+					{Kind: model.KindOrphanNote, Severity: model.SeverityWarning},
+				}))
+				Expect(result.BrokenLinks[self]).To(HaveLen(1))
+			})
+
+			It("suppresses orphan notes via frontmatter", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindOrphanNote: {Severity: model.SeverityError},
+				}
+				note := filepath.Join(tempDir, "Note.md")
+				content := "---\nobsidian-lint:\n  ignore: [orphan-note]\n---\nText"
+				Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks).To(BeEmpty())
+			})
+
+			It("reports unused attachments when enabled", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindUnusedAttachment: {Severity: model.SeverityWarning},
+				}
+				note := filepath.Join(tempDir, "Note.md")
+				canvas := filepath.Join(tempDir, "Board.canvas")
+				embedded := filepath.Join(tempDir, "embedded.png")
+				onCanvas := filepath.Join(tempDir, "canvas.pdf")
+				unused := filepath.Join(tempDir, "unused.png")
+				Expect(os.WriteFile(note, []byte("![[embedded.png]]"), 0600)).To(Succeed())
+				Expect(os.WriteFile(canvas, []byte(`{"nodes":[{"type":"file","file":"canvas.pdf"}]}`), 0600)).
+					To(Succeed())
+				Expect(os.WriteFile(embedded, []byte("png"), 0600)).To(Succeed())
+				Expect(os.WriteFile(onCanvas, []byte("pdf"), 0600)).To(Succeed())
+				Expect(os.WriteFile(unused, []byte("unused"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks).To(HaveLen(1))
+				Expect(result.BrokenLinks[unused]).To(Equal([]model.BrokenLink{
+					{Kind: model.KindUnusedAttachment, Severity: model.SeverityWarning, Size: 6},
+				}))
+			})
+
+			It("only reports attachment types and files in the attachment folder", func() {
+				cfg.Rules = map[model.Kind]config.Rule{
+					model.KindUnusedAttachment: {Severity: model.SeverityWarning},
+				}
+				cfg.Attachments.Folder = "Files"
+				Expect(os.MkdirAll(filepath.Join(tempDir, "Files"), 0750)).To(Succeed())
+				note := filepath.Join(tempDir, "Note.md")
+				content := `<img src="logo.png" width="100"> <a href='Files/guide.epub'>guide</a>`
+				Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+				unused := filepath.Join(tempDir, "Files", "data.csv")
+				for _, file := range []string{
+					"README.txt", "sync.sh", "logo.png", "Files/guide.epub", "Files/data.csv",
+				} {
+					path := filepath.Join(tempDir, filepath.FromSlash(file))
+					Expect(os.WriteFile(path, []byte("data"), 0600)).To(Succeed())
+				}
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks).To(HaveLen(1))
+				Expect(result.BrokenLinks[unused]).To(Equal([]model.BrokenLink{
+					{Kind: model.KindUnusedAttachment, Severity: model.SeverityWarning, Size: 4},
+				}))
+			})
+
+			It("skips allowed dangling targets", func() {
+				cfg.AllowedTargets = []string{"Ideas/*"}
+				note := filepath.Join(tempDir, "Note.md")
+				Expect(os.WriteFile(note, []byte("[[Ideas/Later]] [[Missing]]"), 0600)).To(Succeed())
+
+				result, err := v.Validate(ctx, tempDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.BrokenLinks[note]).To(HaveLen(1))
+				Expect(result.BrokenLinks[note][0].Link).To(Equal("[[Missing]]"))
+			})
+		})
+
+		It("computes fix for broken link with one confident match", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "Work"), 0750)).To(Succeed())
+			plan := filepath.Join(tempDir, "Work", "Project Plan.md")
+			note := filepath.Join(tempDir, "Note.md")
+			content := "![[Projct Plan#Goals|plan]]\n[plan](Work/Projct%20Plan.md#Goals)\n[[Unrelated]]"
+			Expect(os.WriteFile(plan, []byte("# Goals"), 0600)).To(Succeed())
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(3))
+			Expect(result.BrokenLinks[note][0].Fix).To(Equal("![[Project Plan#Goals|plan]]"))
+			Expect(result.BrokenLinks[note][1].Fix).
+				To(Equal("[plan](Work/Project%20Plan.md#Goals)"))
+			Expect(result.BrokenLinks[note][2].Fix).To(BeEmpty())
+		})
+
+		It("suggests nearest targets", func() {
+			for _, name := range []string{"Meeting A", "Meeting B", "Meeting C", "Meeting D"} {
+				path := filepath.Join(tempDir, name+".md")
+				Expect(os.WriteFile(path, nil, 0600)).To(Succeed())
+			}
+			note := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(note, []byte("[[Meeting]] [[Xyz]]"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(2))
+			Expect(result.BrokenLinks[note][0].Suggestions).
+				To(Equal([]string{"Meeting A", "Meeting B", "Meeting C"}))
+			Expect(result.BrokenLinks[note][1].Suggestions).To(BeEmpty())
+		})
+
+		It("computes no fix if several notes match equally", func() {
+			Expect(os.MkdirAll(filepath.Join(tempDir, "A"), 0750)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "B"), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "A", "Meeting.md"), nil, 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "B", "Meeting.md"), nil, 0600)).To(Succeed())
+			note := filepath.Join(tempDir, "Note.md")
+			Expect(os.WriteFile(note, []byte("[[A/Meetng]]"), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note][0].Fix).To(BeEmpty())
+		})
+
+		It("honors suppression comments", func() {
+			note := filepath.Join(tempDir, "Note.md")
+			content := "%% obsidian-lint-disable-next-line broken-link %%\n[[Later]]\n[[Missing]]"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note][0].Link).To(Equal("[[Missing]]"))
+		})
+
+		It("reports unused suppressions", func() {
+			note := filepath.Join(tempDir, "Note.md")
+			content := "%% obsidian-lint-disable-next-line broken-heading %%\n[[Missing]]"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(2))
+			Expect(result.BrokenLinks[note][0].Kind).To(Equal(model.KindBrokenLink))
+			Expect(result.BrokenLinks[note][1].Kind).To(Equal(model.KindUnusedSuppression))
+			Expect(result.BrokenLinks[note][1].Line).To(Equal(1))
+			Expect(result.BrokenLinks[note][1].Severity).To(Equal(model.SeverityWarning))
+		})
+
+		It("reports suppressions of unknown kinds", func() {
+			note := filepath.Join(tempDir, "Note.md")
+			content := "%% obsidian-lint-disable-next-line broken-link, broken-links %%\n" +
+				"[[Missing]]"
+			Expect(os.WriteFile(note, []byte(content), 0600)).To(Succeed())
+
+			result, err := v.Validate(ctx, tempDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[note]).To(HaveLen(1))
+			Expect(result.BrokenLinks[note][0].Kind).To(Equal(model.KindUnusedSuppression))
+			Expect(result.BrokenLinks[note][0].Detail).To(Equal("unknown kind broken-links"))
+		})
+
 		It("returns empty result for vault with no broken links", func() {
 			note1 := filepath.Join(tempDir, "Note1.md")
 			note2 := filepath.Join(tempDir, "Note2.md")
@@ -169,4 +580,49 @@ Content`), 0600)).To(Succeed())
 			Expect(result.BrokenLinks).To(BeEmpty())
 		})
 	})
+
+	Context("ValidateDiff", func() {
+		var changed, unchanged, linking, removed string
+
+		BeforeEach(func() {
+			changed = filepath.Join(tempDir, "Changed.md")
+			unchanged = filepath.Join(tempDir, "Unchanged.md")
+			linking = filepath.Join(tempDir, "Folder", "Linking.md")
+			removed = filepath.Join(tempDir, "Old", "Removed.md")
+
+			Expect(os.MkdirAll(filepath.Dir(linking), 0750)).To(Succeed())
+			Expect(os.WriteFile(changed, []byte("[[Missing1]]"), 0600)).To(Succeed())
+			Expect(os.WriteFile(unchanged, []byte("[[Missing2]]"), 0600)).To(Succeed())
+			Expect(os.WriteFile(linking, []byte("[[Old/Removed]]"), 0600)).To(Succeed())
+		})
+
+		It("only reports changed notes and notes linking to removed files", func() {
+			result, err := v.ValidateDiff(ctx, tempDir, &git.Diff{
+				Changed: []string{changed},
+				Removed: []string{removed},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Files).To(ConsistOf(changed, linking))
+			Expect(result.BrokenLinks).To(HaveLen(2))
+			Expect(result.BrokenLinks).To(HaveKey(changed))
+			Expect(result.BrokenLinks).To(HaveKey(linking))
+		})
+
+		It("validates the staged content of changed notes", func() {
+			result, err := v.ValidateDiff(ctx, tempDir, &git.Diff{
+				Changed:  []string{changed},
+				Contents: map[string]string{changed: "[[Unchanged]] [[Staged]]"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.BrokenLinks[changed]).To(HaveLen(1))
+			Expect(result.BrokenLinks[changed][0].Link).To(Equal("[[Staged]]"))
+		})
+
+		It("reports nothing for an empty diff", func() {
+			result, err := v.ValidateDiff(ctx, tempDir, &git.Diff{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Files).To(BeEmpty())
+			Expect(result.BrokenLinks).To(BeEmpty())
+		})
+	})
 })
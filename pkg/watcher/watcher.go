@@ -0,0 +1,313 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bborbe/errors"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/formatter"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/model"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/validator"
+)
+
+// debounce is the time to wait for further events before the vault is validated
+// again, so saving or moving many files at once prints a single delta
+const debounce = 200 * time.Millisecond
+
+//counterfeiter:generate -o ../../mocks/watcher.go --fake-name Watcher . Watcher
+
+// Watcher validates a vault again whenever files change
+type Watcher interface {
+	// Watch prints all findings of the vault, then the findings added and fixed by
+	// every change until ctx is canceled
+	Watch(ctx context.Context, vaultPath string) error
+}
+
+// New creates a new Watcher writing findings formatted by formatter to out
+func New(
+	scanner scanner.Scanner,
+	parser parser.Parser,
+	indexBuilder index.Builder,
+	validator validator.Validator,
+	matcher exclude.Matcher,
+	formatter formatter.Formatter,
+	out io.Writer,
+) Watcher {
+	return &watcher{
+		scanner:      scanner,
+		parser:       parser,
+		indexBuilder: indexBuilder,
+		validator:    validator,
+		matcher:      matcher,
+		formatter:    formatter,
+		out:          out,
+	}
+}
+
+type watcher struct {
+	scanner      scanner.Scanner
+	parser       parser.Parser
+	indexBuilder index.Builder
+	validator    validator.Validator
+	matcher      exclude.Matcher
+	formatter    formatter.Formatter
+	out          io.Writer
+}
+
+// Watch keeps the vault index in memory and only parses changed files again
+func (w *watcher) Watch(ctx context.Context, vaultPath string) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(ctx, err, "create file watcher failed")
+	}
+	defer func() { _ = fsWatcher.Close() }()
+
+	// Subscribe before indexing, so no change is missed
+	if err := w.addFolder(ctx, fsWatcher, nil, vaultPath, vaultPath); err != nil {
+		return err
+	}
+
+	files, err := w.scanner.Scan(ctx, vaultPath)
+	if err != nil {
+		return errors.Wrap(ctx, err, "scan failed")
+	}
+	idx, err := w.indexBuilder.Build(ctx, vaultPath, files)
+	if err != nil {
+		return errors.Wrap(ctx, err, "build index failed")
+	}
+	result, err := w.validator.ValidateIndex(ctx, idx)
+	if err != nil {
+		return err
+	}
+	if err := w.printDelta(ctx, &model.ValidationResult{}, result); err != nil {
+		return err
+	}
+
+	pending := make(map[string]struct{})
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.excluded(vaultPath, event.Name) {
+				pending[event.Name] = struct{}{}
+				timer.Reset(debounce)
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			return errors.Wrap(ctx, err, "watch vault failed")
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]struct{})
+
+			for _, path := range paths {
+				if err := w.update(ctx, fsWatcher, idx, vaultPath, path); err != nil {
+					return err
+				}
+			}
+			current, err := w.validator.ValidateIndex(ctx, idx)
+			if err != nil {
+				return err
+			}
+			if err := w.printDelta(ctx, result, current); err != nil {
+				return err
+			}
+			result = current
+		}
+	}
+}
+
+// update applies the current state of a changed path to the index
+func (w *watcher) update(
+	ctx context.Context,
+	fsWatcher *fsnotify.Watcher,
+	idx *index.VaultIndex,
+	vaultPath string,
+	path string,
+) error {
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		// Deleted or renamed away, renamed files show up as created at the new path
+		idx.Remove(path)
+		return nil
+	case err != nil:
+		return errors.Wrap(ctx, err, "stat changed file failed")
+	case info.IsDir():
+		return w.addFolder(ctx, fsWatcher, idx, vaultPath, path)
+	default:
+		return w.addFile(ctx, idx, path)
+	}
+}
+
+// addFolder subscribes to all folders below dir that are not excluded and adds
+// their files to idx, unless idx is nil
+func (w *watcher) addFolder(
+	ctx context.Context,
+	fsWatcher *fsnotify.Watcher,
+	idx *index.VaultIndex,
+	vaultPath string,
+	dir string,
+) error {
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if w.excluded(vaultPath, path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return fsWatcher.Add(path)
+		}
+		if idx == nil {
+			return nil
+		}
+		return w.addFile(ctx, idx, path)
+	})
+	if err != nil {
+		return errors.Wrapf(ctx, err, "watch folder %s failed", dir)
+	}
+	return nil
+}
+
+// addFile parses markdown notes and adds the file to idx
+func (w *watcher) addFile(ctx context.Context, idx *index.VaultIndex, path string) error {
+	if filepath.Ext(path) != ".md" {
+		idx.Add(path, nil)
+		return nil
+	}
+
+	parsed, err := w.parser.Parse(ctx, path)
+	if err != nil {
+		if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+			// Removed again before it was parsed
+			idx.Remove(path)
+			return nil
+		}
+		return errors.Wrapf(ctx, err, "parse %s failed", path)
+	}
+	idx.Add(path, parsed)
+	return nil
+}
+
+// excluded returns true for paths outside the vault or excluded by the matcher
+func (w *watcher) excluded(vaultPath string, path string) bool {
+	relPath, err := filepath.Rel(vaultPath, path)
+	if err != nil {
+		return true
+	}
+	if relPath == "." {
+		return false
+	}
+	return w.matcher.Match(filepath.ToSlash(relPath))
+}
+
+// printDelta prints the findings of current missing in previous as new and the
+// findings of previous missing in current as fixed
+func (w *watcher) printDelta(
+	ctx context.Context,
+	previous *model.ValidationResult,
+	current *model.ValidationResult,
+) error {
+	added := subtract(current, previous)
+	fixed := subtract(previous, current)
+	if len(added.BrokenLinks) == 0 && len(fixed.BrokenLinks) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(
+		w.out,
+		"%s: %d new, %d fixed\n",
+		time.Now().Format(time.TimeOnly),
+		count(added),
+		count(fixed),
+	)
+	for _, section := range []struct {
+		title  string
+		result *model.ValidationResult
+	}{
+		{title: "New", result: added},
+		{title: "Fixed", result: fixed},
+	} {
+		if len(section.result.BrokenLinks) == 0 {
+			continue
+		}
+		output, err := w.formatter.Format(ctx, section.result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w.out, "%s:\n%s\n", section.title, output)
+	}
+	return nil
+}
+
+// subtract returns the findings of a that are not in b. Findings are compared
+// without their position, so editing a note does not report the findings that
+// only moved to another line.
+func subtract(a *model.ValidationResult, b *model.ValidationResult) *model.ValidationResult {
+	result := &model.ValidationResult{
+		VaultPath:   a.VaultPath,
+		Files:       a.Files,
+		BrokenLinks: make(map[string][]model.BrokenLink),
+		Severities:  a.Severities,
+	}
+	for file, links := range a.BrokenLinks {
+		known := make(map[string]int, len(b.BrokenLinks[file]))
+		for _, link := range b.BrokenLinks[file] {
+			known[key(link)]++
+		}
+		for _, link := range links {
+			if known[key(link)] > 0 {
+				known[key(link)]--
+				continue
+			}
+			result.BrokenLinks[file] = append(result.BrokenLinks[file], link)
+		}
+	}
+	return result
+}
+
+// key identifies a finding within a file
+func key(link model.BrokenLink) string {
+	return string(link.Kind) + "\x00" + link.Link
+}
+
+// count returns the number of findings in result
+func count(result *model.ValidationResult) int {
+	n := 0
+	for _, links := range result.BrokenLinks {
+		n += len(links)
+	}
+	return n
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package watcher_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/bborbe/obsidian-lint/pkg/config"
+	"github.com/bborbe/obsidian-lint/pkg/exclude"
+	"github.com/bborbe/obsidian-lint/pkg/formatter"
+	"github.com/bborbe/obsidian-lint/pkg/index"
+	"github.com/bborbe/obsidian-lint/pkg/parser"
+	"github.com/bborbe/obsidian-lint/pkg/resolver"
+	"github.com/bborbe/obsidian-lint/pkg/scanner"
+	"github.com/bborbe/obsidian-lint/pkg/schema"
+	"github.com/bborbe/obsidian-lint/pkg/suggest"
+	"github.com/bborbe/obsidian-lint/pkg/validator"
+	"github.com/bborbe/obsidian-lint/pkg/watcher"
+)
+
+var _ = Describe("Watcher", func() {
+	var (
+		ctx     context.Context
+		cancel  context.CancelFunc
+		tempDir string
+		out     *gbytes.Buffer
+		done    chan error
+		err     error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		tempDir, err = os.MkdirTemp("", "watcher-test")
+		Expect(err).NotTo(HaveOccurred())
+		out = gbytes.NewBuffer()
+
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "Source.md"),
+			[]byte("[[Target]] and [[Missing]]"),
+			0600,
+		)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(tempDir, "Target.md"), []byte("# Target"), 0600)).
+			To(Succeed())
+
+		m := exclude.New(nil, nil)
+		s := scanner.New(m)
+		p := parser.New()
+		b := index.New(p, m, 0)
+		cfg := config.Default()
+		r := resolver.New(cfg)
+		v := validator.New(s, p, b, r, suggest.New(), schema.New(nil), cfg)
+		w := watcher.New(s, p, b, v, m, formatter.NewTextFormatter(), out)
+
+		done = make(chan error, 1)
+		go func() {
+			done <- w.Watch(ctx, tempDir)
+		}()
+		Eventually(out).Should(gbytes.Say(`1 new, 0 fixed`))
+		Eventually(out).Should(gbytes.Say(`\[\[Missing\]\]`))
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(done, 5*time.Second).Should(Receive(BeNil()))
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("reports links dangling after a rename", func() {
+		Expect(os.Rename(
+			filepath.Join(tempDir, "Target.md"),
+			filepath.Join(tempDir, "Renamed.md"),
+		)).To(Succeed())
+
+		Eventually(out, 5*time.Second).Should(gbytes.Say(`1 new, 0 fixed`))
+		Eventually(out).Should(gbytes.Say(`\[\[Target\]\]`))
+	})
+
+	It("reports fixed links when the target is created", func() {
+		Expect(os.WriteFile(filepath.Join(tempDir, "Missing.md"), []byte("content"), 0600)).
+			To(Succeed())
+
+		Eventually(out, 5*time.Second).Should(gbytes.Say(`0 new, 1 fixed`))
+		Eventually(out).Should(gbytes.Say(`(?s)Fixed:\n.*\[\[Missing\]\]`))
+	})
+
+	It("picks up notes in new folders", func() {
+		folder := filepath.Join(tempDir, "Folder")
+		Expect(os.MkdirAll(folder, 0750)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(folder, "New.md"), []byte("[[Gone]]"), 0600)).
+			To(Succeed())
+
+		Eventually(out, 5*time.Second).Should(gbytes.Say(`1 new, 0 fixed`))
+		Eventually(out).Should(gbytes.Say(`\[\[Gone\]\]`))
+	})
+
+	It("only prints changed findings after editing a note", func() {
+		Expect(os.WriteFile(
+			filepath.Join(tempDir, "Source.md"),
+			[]byte("New first line\n[[Target]] and [[Missing]] and [[Other]]"),
+			0600,
+		)).To(Succeed())
+
+		Eventually(out, 5*time.Second).Should(gbytes.Say(`1 new, 0 fixed`))
+		Eventually(out).Should(gbytes.Say(`\[\[Other\]\]`))
+		Consistently(out, 500*time.Millisecond).ShouldNot(gbytes.Say(`Missing`))
+	})
+})